@@ -0,0 +1,177 @@
+package vcd
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	govcd "github.com/vmware/go-vcloud-director/govcd"
+	types "github.com/vmware/go-vcloud-director/types/v56"
+)
+
+// accessControlSchema returns the fields shared by the vcd_*_access
+// resources, merged into the fields identifying the entity. validateLevel
+// checks the access levels the entity accepts.
+func accessControlSchema(entity map[string]*schema.Schema, validateLevel schema.SchemaValidateFunc) map[string]*schema.Schema {
+	entity["everyone"] = &schema.Schema{
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     false,
+		Description: "Share with everyone in the org",
+	}
+	entity["everyone_access_level"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Default:      "ReadOnly",
+		ValidateFunc: validateLevel,
+	}
+	entity["access"] = &schema.Schema{
+		Type:     schema.TypeSet,
+		Optional: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"subject_type": &schema.Schema{
+					Type:         schema.TypeString,
+					Required:     true,
+					Description:  "One of user, group or org",
+					ValidateFunc: validateAccessSubjectType,
+				},
+
+				"subject_name": &schema.Schema{
+					Type:     schema.TypeString,
+					Required: true,
+				},
+
+				"access_level": &schema.Schema{
+					Type:         schema.TypeString,
+					Required:     true,
+					ValidateFunc: validateLevel,
+				},
+			},
+		},
+	}
+	return entity
+}
+
+var accessSubjectTypes = map[string]string{
+	"user":  types.MimeAdminUser,
+	"group": types.MimeAdminGroup,
+	"org":   types.MimeAdminOrg,
+}
+
+func validateAccessLevel(v interface{}, k string) ([]string, []error) {
+	switch v.(string) {
+	case "ReadOnly", "Change", "FullControl":
+		return nil, nil
+	}
+	return nil, []error{fmt.Errorf("%s must be one of ReadOnly, Change or FullControl, got %s", k, v.(string))}
+}
+
+// vCD only lets VDCs be shared read-only
+func validateVdcAccessLevel(v interface{}, k string) ([]string, []error) {
+	if v.(string) != "ReadOnly" {
+		return nil, []error{fmt.Errorf("%s must be ReadOnly for a VDC, got %s", k, v.(string))}
+	}
+	return nil, nil
+}
+
+func validateAccessSubjectType(v interface{}, k string) ([]string, []error) {
+	if _, ok := accessSubjectTypes[v.(string)]; !ok {
+		return nil, []error{fmt.Errorf("%s must be one of user, group or org, got %s", k, v.(string))}
+	}
+	return nil, nil
+}
+
+// expandControlAccessParams builds the complete ACL from the configuration,
+// resolving each subject name to its HREF.
+func expandControlAccessParams(d *schema.ResourceData, vcdClient *VCDClient) (*types.ControlAccessParams, error) {
+	params := &types.ControlAccessParams{
+		IsSharedToEveryone: d.Get("everyone").(bool),
+	}
+	if params.IsSharedToEveryone {
+		params.EveryoneAccessLevel = d.Get("everyone_access_level").(string)
+	}
+
+	configured := d.Get("access").(*schema.Set).List()
+	if len(configured) == 0 {
+		return params, nil
+	}
+
+	adminOrg, err := getAdminOrg(vcdClient, vcdClient.orgName(d))
+	if err != nil {
+		return nil, err
+	}
+
+	settings := make([]*types.AccessSetting, 0, len(configured))
+	for _, raw := range configured {
+		data := raw.(map[string]interface{})
+		subject, err := getAccessSubject(vcdClient, &adminOrg, data["subject_type"].(string), data["subject_name"].(string))
+		if err != nil {
+			return nil, err
+		}
+		settings = append(settings, &types.AccessSetting{
+			Subject:     subject,
+			AccessLevel: data["access_level"].(string),
+		})
+	}
+	params.AccessSettings = &types.AccessSettingList{AccessSetting: settings}
+
+	return params, nil
+}
+
+func getAccessSubject(vcdClient *VCDClient, adminOrg *govcd.AdminOrg, subjectType, name string) (*types.Reference, error) {
+	var href string
+	switch subjectType {
+	case "user":
+		user, err := adminOrg.GetUserByName(name)
+		if err != nil || user == (govcd.OrgUser{}) {
+			return nil, fmt.Errorf("Could not find user: %s with error %v", name, err)
+		}
+		href = user.User.HREF
+	case "group":
+		group, err := adminOrg.GetGroupByName(name)
+		if err != nil || group == (govcd.OrgGroup{}) {
+			return nil, fmt.Errorf("Could not find group: %s with error %v", name, err)
+		}
+		href = group.Group.HREF
+	case "org":
+		org, err := getAdminOrg(vcdClient, name)
+		if err != nil {
+			return nil, err
+		}
+		href = org.AdminOrg.HREF
+	}
+	return &types.Reference{
+		HREF: href,
+		Type: accessSubjectTypes[subjectType],
+		Name: name,
+	}, nil
+}
+
+// flattenControlAccessParams stores the ACL read back from vCD.
+func flattenControlAccessParams(d *schema.ResourceData, params *types.ControlAccessParams) {
+	d.Set("everyone", params.IsSharedToEveryone)
+	if params.IsSharedToEveryone {
+		d.Set("everyone_access_level", params.EveryoneAccessLevel)
+	}
+
+	access := make([]interface{}, 0)
+	if params.AccessSettings != nil {
+		for _, setting := range params.AccessSettings.AccessSetting {
+			if setting.Subject == nil {
+				continue
+			}
+			subjectType := ""
+			for name, mime := range accessSubjectTypes {
+				if mime == setting.Subject.Type {
+					subjectType = name
+				}
+			}
+			access = append(access, map[string]interface{}{
+				"subject_type": subjectType,
+				"subject_name": setting.Subject.Name,
+				"access_level": setting.AccessLevel,
+			})
+		}
+	}
+	d.Set("access", access)
+}
@@ -0,0 +1,403 @@
+package vcd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+
+	govcd "github.com/vmware/go-vcloud-director/govcd"
+)
+
+const (
+	// Set to "record" to save the vCD traffic of the acceptance tests
+	// to cassettes, or to "replay" to serve it back from them
+	cassetteModeEnv = "VCD_CASSETTE_MODE"
+
+	cassetteDir = "testdata/cassettes"
+	redacted    = "REDACTED"
+)
+
+// The environment a recording was made in, restored on replay so that
+// the tests build the same requests. The password isn't kept.
+var cassetteEnvironment = []string{
+	"VCD_URL",
+	"VCD_USER",
+	"VCD_ORG",
+	"VCD_TEST_ORG",
+	"VCD_VDC",
+	"VCD_EDGE_GATEWAY",
+	"VCD_EXTERNAL_IP",
+	"VCD_ALLOW_UNVERIFIED_SSL",
+}
+
+var whitespaceBetweenTags = regexp.MustCompile(`>\s+<`)
+
+type cassetteInteraction struct {
+	Method         string      `json:"method"`
+	URL            string      `json:"url"`
+	RequestBody    string      `json:"request_body,omitempty"`
+	StatusCode     int         `json:"status_code"`
+	ResponseHeader http.Header `json:"response_header,omitempty"`
+	ResponseBody   string      `json:"response_body,omitempty"`
+}
+
+type cassette struct {
+	Interactions []*cassetteInteraction `json:"interactions"`
+}
+
+// cassetteRecorder records the vCD traffic of each acceptance test to a
+// cassette named after the test, or replays it from there. Requests are
+// matched on their method and URL, and on their normalized body unless
+// they are GETs, in the order they were recorded; a GET that was made
+// more times than when recording gets the last recorded response again,
+// as for a task polled more often.
+type cassetteRecorder struct {
+	recording bool
+
+	lock     sync.Mutex
+	name     string    // Test the current cassette belongs to
+	cassette *cassette // Nil between tests
+	replayed map[string]int
+	secrets  []string // Replaced in everything recorded
+}
+
+// Returns the recorder for mode, nil if the tests don't use cassettes.
+func newCassetteRecorder(mode string) (*cassetteRecorder, error) {
+	switch mode {
+	case "":
+		return nil, nil
+	case "record", "replay":
+		return &cassetteRecorder{recording: mode == "record"}, nil
+	}
+	return nil, fmt.Errorf("%s must be record or replay, got %q", cassetteModeEnv, mode)
+}
+
+func cassettePath(name string) string {
+	return filepath.Join(cassetteDir, strings.Replace(name, "/", "_", -1)+".json")
+}
+
+// saveEnvironment keeps the environment of a recording next to its
+// cassettes, and notes the password to scrub from them.
+func (r *cassetteRecorder) saveEnvironment() error {
+	if password := os.Getenv("VCD_PASSWORD"); password != "" {
+		r.secrets = append(r.secrets, password)
+	}
+	env := make(map[string]string)
+	for _, key := range cassetteEnvironment {
+		env[key] = os.Getenv(key)
+	}
+	return writeCassetteJSON(filepath.Join(cassetteDir, "environment.json"), env)
+}
+
+// loadEnvironment restores the environment the cassettes were recorded in.
+func (r *cassetteRecorder) loadEnvironment() error {
+	body, err := ioutil.ReadFile(filepath.Join(cassetteDir, "environment.json"))
+	if err != nil {
+		return fmt.Errorf("error reading cassette environment: %s", err)
+	}
+	env := make(map[string]string)
+	if err := json.Unmarshal(body, &env); err != nil {
+		return fmt.Errorf("error decoding cassette environment: %s", err)
+	}
+	setTestEnv(env)
+	os.Setenv("VCD_PASSWORD", redacted)
+	return nil
+}
+
+// start switches to the cassette of test t, recording it until the end
+// of the test or loading it for replay. Tests without a cassette to
+// replay are skipped.
+func (r *cassetteRecorder) start(t *testing.T) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if r.name == t.Name() {
+		return
+	}
+
+	path := cassettePath(t.Name())
+	c := new(cassette)
+	if !r.recording {
+		body, err := ioutil.ReadFile(path)
+		if os.IsNotExist(err) {
+			t.Skipf("No cassette recorded for %s", t.Name())
+		}
+		if err != nil {
+			t.Fatalf("error reading cassette: %s", err)
+		}
+		if err := json.Unmarshal(body, c); err != nil {
+			t.Fatalf("error decoding cassette %s: %s", path, err)
+		}
+	}
+	r.name = t.Name()
+	r.cassette = c
+	r.replayed = make(map[string]int)
+
+	t.Cleanup(func() {
+		r.lock.Lock()
+		defer r.lock.Unlock()
+		if r.recording && !t.Skipped() {
+			if err := writeCassetteJSON(path, r.scrubCassette(c)); err != nil {
+				t.Errorf("error saving cassette: %s", err)
+			}
+		}
+		r.name = ""
+		r.cassette = nil
+	})
+}
+
+// wrap returns the transport recording or replaying through r, used as
+// wrapTransport.
+func (r *cassetteRecorder) wrap(base http.RoundTripper) http.RoundTripper {
+	return &cassetteTransport{base: base, recorder: r}
+}
+
+type cassetteTransport struct {
+	base     http.RoundTripper
+	recorder *cassetteRecorder
+}
+
+func (t *cassetteTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !t.recorder.recording {
+		return t.recorder.replay(req)
+	}
+
+	var requestBody []byte
+	if req.Body != nil {
+		var err error
+		requestBody, err = ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req.Body = ioutil.NopCloser(bytes.NewReader(requestBody))
+	}
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return resp, err
+	}
+	responseBody, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(responseBody))
+
+	t.recorder.record(&cassetteInteraction{
+		Method:         req.Method,
+		URL:            req.URL.String(),
+		RequestBody:    string(requestBody),
+		StatusCode:     resp.StatusCode,
+		ResponseHeader: resp.Header.Clone(),
+		ResponseBody:   string(responseBody),
+	})
+	return resp, nil
+}
+
+func (r *cassetteRecorder) record(interaction *cassetteInteraction) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	// Session tokens are secrets wherever they show up later on
+	if token := interaction.ResponseHeader.Get("x-vcloud-authorization"); token != "" {
+		r.secrets = append(r.secrets, token)
+	}
+	if r.cassette != nil {
+		r.cassette.Interactions = append(r.cassette.Interactions, interaction)
+	}
+}
+
+func (r *cassetteRecorder) replay(req *http.Request) (*http.Response, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if r.cassette == nil {
+		return nil, fmt.Errorf("no cassette to replay %s %s from", req.Method, req.URL)
+	}
+	var body string
+	if req.Body != nil {
+		requestBody, err := ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		body = normalizeCassetteBody(string(requestBody))
+	}
+
+	key := req.Method + " " + req.URL.String()
+	if req.Method != "GET" {
+		key += " " + body
+	}
+	var found, last *cassetteInteraction
+	seen := 0
+	for _, interaction := range r.cassette.Interactions {
+		recorded := interaction.Method + " " + interaction.URL
+		if interaction.Method != "GET" {
+			recorded += " " + normalizeCassetteBody(interaction.RequestBody)
+		}
+		if recorded != key {
+			continue
+		}
+		last = interaction
+		if seen == r.replayed[key] {
+			found = interaction
+			break
+		}
+		seen++
+	}
+	if found == nil && req.Method == "GET" {
+		found = last
+	}
+	if found == nil {
+		return nil, fmt.Errorf("no recorded response for %s in cassette %s", key, cassettePath(r.name))
+	}
+	r.replayed[key]++
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", found.StatusCode, http.StatusText(found.StatusCode)),
+		StatusCode:    found.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        found.ResponseHeader.Clone(),
+		Body:          ioutil.NopCloser(strings.NewReader(found.ResponseBody)),
+		ContentLength: int64(len(found.ResponseBody)),
+		Request:       req,
+	}, nil
+}
+
+// Returns body as recorded, with secrets scrubbed, and without the
+// indentation of XML documents.
+func normalizeCassetteBody(body string) string {
+	body = govcd.RedactSecrets(body)
+	return strings.TrimSpace(whitespaceBetweenTags.ReplaceAllString(body, "><"))
+}
+
+// Returns c without the secrets recorded in it: the password and session
+// tokens, and the elements API logs redact, such as VPN shared secrets
+// and guest OS passwords. Called with the lock held.
+func (r *cassetteRecorder) scrubCassette(c *cassette) *cassette {
+	scrub := func(s string) string {
+		for _, secret := range r.secrets {
+			s = strings.Replace(s, secret, redacted, -1)
+		}
+		return govcd.RedactSecrets(s)
+	}
+
+	scrubbed := &cassette{}
+	for _, interaction := range c.Interactions {
+		clean := *interaction
+		clean.URL = scrub(clean.URL)
+		clean.RequestBody = scrub(clean.RequestBody)
+		clean.ResponseBody = scrub(clean.ResponseBody)
+		clean.ResponseHeader = interaction.ResponseHeader.Clone()
+		clean.ResponseHeader.Del("Set-Cookie")
+		for key, values := range clean.ResponseHeader {
+			for i := range values {
+				values[i] = scrub(values[i])
+			}
+			clean.ResponseHeader[key] = values
+		}
+		scrubbed.Interactions = append(scrubbed.Interactions, &clean)
+	}
+	return scrubbed
+}
+
+func writeCassetteJSON(path string, v interface{}) error {
+	body, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, append(body, '\n'), 0644)
+}
+
+func TestCassetteScrub(t *testing.T) {
+	r := &cassetteRecorder{recording: true, secrets: []string{"s3cret"}}
+	header := http.Header{}
+	header.Set("x-vcloud-authorization", "token1")
+	r.record(&cassetteInteraction{
+		Method:         "POST",
+		URL:            "https://vcd/api/sessions",
+		StatusCode:     200,
+		ResponseHeader: header,
+	})
+	c := &cassette{Interactions: []*cassetteInteraction{{
+		Method:         "POST",
+		URL:            "https://vcd/api/admin/org/1/users",
+		RequestBody:    "<User><Password>s3cret</Password><Note>token1 s3cret</Note></User>",
+		StatusCode:     201,
+		ResponseHeader: header,
+		ResponseBody:   "<User><vcloud:Password>other</vcloud:Password></User>",
+	}, {
+		Method:       "GET",
+		URL:          "https://vcd/api/admin/edgeGateway/1",
+		StatusCode:   200,
+		ResponseBody: "<Tunnel><SharedSecret>psk</SharedSecret><SharedSecretEncrypted>true</SharedSecretEncrypted></Tunnel><AdminPassword>guest</AdminPassword>",
+	}}}
+
+	scrubbedCassette := r.scrubCassette(c)
+	scrubbed := scrubbedCassette.Interactions[0]
+	if got, want := scrubbed.RequestBody, "<User><Password>REDACTED</Password><Note>REDACTED REDACTED</Note></User>"; got != want {
+		t.Errorf("request body scrubbed to %q, want %q", got, want)
+	}
+	if got, want := scrubbed.ResponseBody, "<User><vcloud:Password>REDACTED</vcloud:Password></User>"; got != want {
+		t.Errorf("response body scrubbed to %q, want %q", got, want)
+	}
+	if got := scrubbed.ResponseHeader.Get("x-vcloud-authorization"); got != redacted {
+		t.Errorf("token header scrubbed to %q", got)
+	}
+	if got, want := scrubbedCassette.Interactions[1].ResponseBody, "<Tunnel><SharedSecret>REDACTED</SharedSecret><SharedSecretEncrypted>REDACTED</SharedSecretEncrypted></Tunnel><AdminPassword>REDACTED</AdminPassword>"; got != want {
+		t.Errorf("edge gateway scrubbed to %q, want %q", got, want)
+	}
+	if header.Get("x-vcloud-authorization") != "token1" {
+		t.Errorf("scrubbing changed the recorded interaction")
+	}
+}
+
+func TestCassetteReplayMatchesBodies(t *testing.T) {
+	r := &cassetteRecorder{
+		name: t.Name(),
+		cassette: &cassette{Interactions: []*cassetteInteraction{{
+			Method:       "POST",
+			URL:          "https://vcd/api/admin/org/1/users",
+			RequestBody:  "<User name=\"a\">\n    <Password>REDACTED</Password>\n</User>",
+			StatusCode:   201,
+			ResponseBody: "a",
+		}, {
+			Method:       "POST",
+			URL:          "https://vcd/api/admin/org/1/users",
+			RequestBody:  "<User name=\"b\"></User>",
+			StatusCode:   201,
+			ResponseBody: "b",
+		}}},
+		replayed: make(map[string]int),
+	}
+	post := func(body string) (string, error) {
+		req, _ := http.NewRequest("POST", "https://vcd/api/admin/org/1/users", strings.NewReader(body))
+		resp, err := r.replay(req)
+		if err != nil {
+			return "", err
+		}
+		replayed, _ := ioutil.ReadAll(resp.Body)
+		return string(replayed), nil
+	}
+
+	// Out of the recorded order, and indented differently
+	if got, err := post(`<User name="b"></User>`); err != nil || got != "b" {
+		t.Errorf("replayed %q, %v for user b", got, err)
+	}
+	if got, err := post(`<User name="a"><Password>s3cret</Password></User>`); err != nil || got != "a" {
+		t.Errorf("replayed %q, %v for user a", got, err)
+	}
+	if _, err := post(`<User name="c"></User>`); err == nil {
+		t.Errorf("replayed a response for a body that wasn't recorded")
+	}
+}
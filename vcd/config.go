@@ -2,24 +2,133 @@ package vcd
 
 import (
 	"fmt"
+	"log"
+	"net/http"
 	"net/url"
+	"os"
+	"regexp"
+	"strings"
 
+	"github.com/hashicorp/terraform/helper/schema"
 	govcd "github.com/vmware/go-vcloud-director/govcd"
 )
 
 type Config struct {
 	User            string
 	Password        string
-	Org             string
+	Token           string
+	AuthType        string
+	SysOrg          string // Org used to log in, if different from Org
+	Org             string // Default tenant org
+	Vdc             string // Default VDC, optional
 	Href            string
 	MaxRetryTimeout int
 	InsecureFlag    bool
+	CacheToken      bool
+	APIVersion      string // Pinned API version, negotiated at login if empty
+	CacheLookups    bool
+	APILogFile      string // Appends the API calls passing the filters below, if set
+	APILogMethods   string // Comma separated HTTP methods
+	APILogURL       string // Regular expression matching request URLs
+	APILogStatuses  string // Comma separated status codes or classes, such as 5xx
 }
 
+// wrapTransport, when set, wraps the HTTP transport of new clients before
+// they log in. The tests use it to record and replay vCD traffic.
+var wrapTransport func(http.RoundTripper) http.RoundTripper
+
 type VCDClient struct {
 	*govcd.VCDClient
+	SysOrg          string
+	Org             string // Default org of resources
+	Vdc             string // Default VDC of resources, may be empty
 	MaxRetryTimeout int
 	InsecureFlag    bool
+
+	// Serialises changes to each edge gateway, by HREF
+	edgeGatewayLocks *mutexKV
+	// Changes to edge gateway services waiting to be submitted together
+	edgeGatewayBatches *edgeGatewayBatches
+	// Orgs, VDCs, catalogs and edge gateways looked up by name, nil if
+	// lookups aren't cached
+	lookupCache *lookupCache
+}
+
+// lockEdgeGateway waits until no other resource is changing the edge
+// gateway, which rejects changes while it applies a previous one. The
+// gateway must be refreshed once locked, to see changes made meanwhile.
+func (c *VCDClient) lockEdgeGateway(href string) {
+	c.edgeGatewayLocks.Lock(href)
+}
+
+func (c *VCDClient) unlockEdgeGateway(href string) {
+	c.edgeGatewayLocks.Unlock(href)
+}
+
+// orgName returns the org argument of a resource, or the provider org if
+// the resource doesn't set it.
+func (c *VCDClient) orgName(d *schema.ResourceData) string {
+	if v, ok := d.GetOk("org"); ok {
+		return v.(string)
+	}
+	return c.Org
+}
+
+// vdcName returns the vdc argument of a resource, or the provider vdc if
+// the resource doesn't set it.
+func (c *VCDClient) vdcName(d *schema.ResourceData) string {
+	if v, ok := d.GetOk("vdc"); ok {
+		return v.(string)
+	}
+	return c.Vdc
+}
+
+// setOrgName records the org a resource is in. The org is computed from the
+// provider org when the resource doesn't set it, and recording it keeps the
+// resource in that org if the provider org changes later.
+func (c *VCDClient) setOrgName(d *schema.ResourceData) {
+	d.Set("org", c.orgName(d))
+}
+
+// setOrgAndVdcNames records the org and VDC a resource is in, see
+// setOrgName.
+func (c *VCDClient) setOrgAndVdcNames(d *schema.ResourceData) {
+	d.Set("org", c.orgName(d))
+	d.Set("vdc", c.vdcName(d))
+}
+
+// getOrgFromResource returns the org a resource is in.
+func (c *VCDClient) getOrgFromResource(d *schema.ResourceData) (govcd.Org, error) {
+	orgName := c.orgName(d)
+	org, err := c.getOrg(orgName)
+	if err != nil {
+		return govcd.Org{}, fmt.Errorf("Could not get Org: %s with error %v", orgName, err)
+	}
+	if org == (govcd.Org{}) {
+		return govcd.Org{}, fmt.Errorf("Could not find Org: %s", orgName)
+	}
+	return org, nil
+}
+
+// getOrgAndVdc returns the org and VDC a resource is in.
+func (c *VCDClient) getOrgAndVdc(d *schema.ResourceData) (govcd.Org, govcd.Vdc, error) {
+	org, err := c.getOrgFromResource(d)
+	if err != nil {
+		return govcd.Org{}, govcd.Vdc{}, err
+	}
+
+	vdcName := c.vdcName(d)
+	if vdcName == "" {
+		return govcd.Org{}, govcd.Vdc{}, fmt.Errorf("vdc must be set either in the resource or in the provider")
+	}
+	vdc, err := c.getVdc(org, vdcName)
+	if err != nil {
+		return govcd.Org{}, govcd.Vdc{}, fmt.Errorf("Could not get vdc: %s with error %v", vdcName, err)
+	}
+	if vdc == (govcd.Vdc{}) {
+		return govcd.Org{}, govcd.Vdc{}, fmt.Errorf("Could not find vdc: %s", vdcName)
+	}
+	return org, vdc, nil
 }
 
 func (c *Config) Client() (*VCDClient, error) {
@@ -28,14 +137,131 @@ func (c *Config) Client() (*VCDClient, error) {
 		return nil, fmt.Errorf("Something went wrong: %s", err)
 	}
 
+	loginOrg := c.SysOrg
+	if loginOrg == "" {
+		loginOrg = c.Org
+	}
+
 	vcdclient := &VCDClient{
-		govcd.NewVCDClient(*u, c.InsecureFlag),
-		c.MaxRetryTimeout, c.InsecureFlag}
-	err = vcdclient.Authenticate(c.User, c.Password, c.Org)
+		VCDClient:       govcd.NewVCDClient(*u, c.InsecureFlag),
+		SysOrg:          loginOrg,
+		Org:             c.Org,
+		Vdc:             c.Vdc,
+		MaxRetryTimeout: c.MaxRetryTimeout,
+		InsecureFlag:    c.InsecureFlag,
+
+		edgeGatewayLocks:   newMutexKV(),
+		edgeGatewayBatches: newEdgeGatewayBatches(),
+	}
+
+	if c.CacheLookups {
+		vcdclient.lookupCache = newLookupCache()
+	}
+
+	if c.APILogFile != "" {
+		vcdclient.Client.APILogger, err = c.apiLogger()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if wrapTransport != nil {
+		vcdclient.Client.Http.Transport = wrapTransport(vcdclient.Client.Http.Transport)
+	}
+
+	if c.APIVersion != "" {
+		vcdclient.SetAPIVersion(c.APIVersion)
+	}
+
+	if c.AuthType == "token" {
+		if c.Token == "" {
+			return nil, fmt.Errorf("token must be set when auth_type is token")
+		}
+		err = vcdclient.SetToken("x-vcloud-authorization", c.Token)
+		if err != nil {
+			return nil, fmt.Errorf("Something went wrong: %s", err)
+		}
+		return vcdclient, nil
+	}
+
+	if c.User == "" || c.Password == "" {
+		return nil, fmt.Errorf("user and password must be set when auth_type is %s", c.AuthType)
+	}
+
+	var cachePath string
+	if c.CacheToken {
+		cachePath, err = tokenCachePath(c.Href, c.User, loginOrg)
+		if err != nil {
+			log.Printf("[DEBUG] Not caching the vCD token: %s", err)
+		}
+	}
+	if cachePath != "" {
+		// Renewed sessions replace the cached one, so the next run
+		// doesn't start from an expired token
+		vcdclient.OnTokenRenewed = func(_, token string) {
+			if err := writeCachedToken(cachePath, token); err != nil {
+				log.Printf("[DEBUG] Could not cache the vCD token: %s", err)
+			}
+		}
+	}
+	if token := readCachedToken(cachePath); token != "" {
+		err = vcdclient.SetToken("x-vcloud-authorization", token)
+		if err == nil {
+			if c.AuthType == "saml_adfs" {
+				err = vcdclient.SetSAMLADFSCredentials(c.User, c.Password, loginOrg, "")
+			} else {
+				vcdclient.SetCredentials(c.User, c.Password, loginOrg)
+			}
+		}
+		if err == nil {
+			return vcdclient, nil
+		}
+		log.Printf("[DEBUG] Cached vCD token rejected, logging in: %s", err)
+	}
+
+	if c.AuthType == "saml_adfs" {
+		err = vcdclient.AuthenticateWithSAMLADFS(c.User, c.Password, loginOrg, "")
+	} else {
+		err = vcdclient.Authenticate(c.User, c.Password, loginOrg)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("Something went wrong: %s", err)
 	}
-	//vcdclient.Org = org
-	//vcdclient.OrgVdc = vdc
+
+	if cachePath != "" {
+		_, token := vcdclient.Client.Token()
+		if err := writeCachedToken(cachePath, token); err != nil {
+			log.Printf("[DEBUG] Could not cache the vCD token: %s", err)
+		}
+	}
 	return vcdclient, nil
 }
+
+// apiLogger returns the logger appending the API calls passing the
+// filters of c to APILogFile. The file stays open for the life of the
+// provider.
+func (c *Config) apiLogger() (govcd.APILogger, error) {
+	filter := govcd.APILogFilter{
+		Methods:  splitLogFilter(c.APILogMethods),
+		Statuses: splitLogFilter(c.APILogStatuses),
+	}
+	if c.APILogURL != "" {
+		re, err := regexp.Compile(c.APILogURL)
+		if err != nil {
+			return nil, fmt.Errorf("api_log_url is not a valid regular expression: %s", err)
+		}
+		filter.URL = re
+	}
+
+	file, err := os.OpenFile(c.APILogFile, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("error opening API log: %s", err)
+	}
+	return govcd.NewAPILogWriter(file, filter), nil
+}
+
+func splitLogFilter(filter string) []string {
+	return strings.FieldsFunc(filter, func(r rune) bool {
+		return r == ',' || r == ' '
+	})
+}
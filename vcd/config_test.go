@@ -0,0 +1,124 @@
+package vcd
+
+import (
+	"bytes"
+	"encoding/base64"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	govcd "github.com/vmware/go-vcloud-director/govcd"
+)
+
+func TestConfigAPILog(t *testing.T) {
+	if testMockVCD == nil {
+		t.Skip("The API log is only tested against the mock vCD")
+	}
+	path := filepath.Join(t.TempDir(), "api.log")
+	config := Config{
+		User:           mockVCDUser,
+		Password:       mockVCDPassword,
+		Org:            mockVCDOrg,
+		Href:           testMockVCD.URL(),
+		InsecureFlag:   true,
+		APILogFile:     path,
+		APILogMethods:  "POST",
+		APILogStatuses: "2xx, 4xx",
+	}
+	client, err := config.Client()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	// Rejected by the mock, but logged with its body
+	body := `<User><Password>s3cret</Password><SharedSecret type="psk">s3cret</SharedSecret></User>`
+	req := client.Client.NewRequest(nil, "POST", client.Client.VCDHREF, strings.NewReader(body))
+	resp, err := client.Client.Http.Do(req)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	resp.Body.Close()
+	// Filtered out by method
+	if _, err := client.getOrg(mockVCDOrg); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	logged, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	basicAuth := base64.StdEncoding.EncodeToString([]byte(mockVCDUser + "@" + mockVCDOrg + ":" + mockVCDPassword))
+	_, token := client.Client.Token()
+	for _, secret := range []string{"s3cret", token, basicAuth} {
+		if bytes.Contains(logged, []byte(secret)) {
+			t.Errorf("API log contains secret %q:\n%s", secret, logged)
+		}
+	}
+	for _, want := range []string{
+		"POST " + testMockVCD.href("/sessions"),
+		"X-Vcloud-Authorization: REDACTED",
+		`<SharedSecret type="psk">REDACTED</SharedSecret>`,
+		"--- response 404 Not Found",
+		"request mock-request-",
+	} {
+		if !bytes.Contains(logged, []byte(want)) {
+			t.Errorf("API log doesn't contain %q:\n%s", want, logged)
+		}
+	}
+	if bytes.Contains(logged, []byte("GET ")) {
+		t.Errorf("API log contains a request filtered out:\n%s", logged)
+	}
+}
+
+func TestConfigTokenCache(t *testing.T) {
+	if testMockVCD == nil {
+		t.Skip("The token cache is only tested against the mock vCD")
+	}
+	t.Setenv("HOME", t.TempDir())
+	config := Config{
+		User:         mockVCDUser,
+		Password:     mockVCDPassword,
+		Org:          mockVCDOrg,
+		Href:         testMockVCD.URL(),
+		InsecureFlag: true,
+		CacheToken:   true,
+	}
+	path, err := tokenCachePath(config.Href, config.User, config.Org)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	client, err := config.Client()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	_, token := client.Client.Token()
+	if cached := readCachedToken(path); cached != token {
+		t.Fatalf("expected cached token %s, got %s", token, cached)
+	}
+
+	// A second run reuses the cached session
+	client, err = config.Client()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if _, reused := client.Client.Token(); reused != token {
+		t.Fatalf("expected the cached token %s to be reused, got %s", token, reused)
+	}
+
+	// Expire the session, the renewed one replaces it in the cache
+	testMockVCD.lock.Lock()
+	delete(testMockVCD.tokens, token)
+	testMockVCD.lock.Unlock()
+	if _, err := govcd.GetOrgByName(client.VCDClient, mockVCDOrg); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	_, renewed := client.Client.Token()
+	if renewed == token {
+		t.Fatalf("expected the session to be renewed")
+	}
+	if cached := readCachedToken(path); cached != renewed {
+		t.Fatalf("expected cached token %s, got %s", renewed, cached)
+	}
+}
@@ -0,0 +1,141 @@
+package vcd
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	govcd "github.com/vmware/go-vcloud-director/govcd"
+	types "github.com/vmware/go-vcloud-director/types/v56"
+)
+
+func datasourceVcdProviderVdc() *schema.Resource {
+	return &schema.Resource{
+		Read: datasourceVcdProviderVdcRead,
+
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"description": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"href": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"status": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"is_enabled": &schema.Schema{
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+
+			"storage_profiles": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"cpu_capacity": providerVdcCapacitySchema(),
+
+			"memory_capacity": providerVdcCapacitySchema(),
+
+			"storage_capacity": providerVdcCapacitySchema(),
+		},
+	}
+}
+
+func providerVdcCapacitySchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Computed: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"units": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"allocation": {
+					Type:     schema.TypeInt,
+					Computed: true,
+				},
+				"reserved": {
+					Type:     schema.TypeInt,
+					Computed: true,
+				},
+				"total": {
+					Type:     schema.TypeInt,
+					Computed: true,
+				},
+				"used": {
+					Type:     schema.TypeInt,
+					Computed: true,
+				},
+				"overhead": {
+					Type:     schema.TypeInt,
+					Computed: true,
+				},
+			},
+		},
+	}
+}
+
+func datasourceVcdProviderVdcRead(d *schema.ResourceData, meta interface{}) error {
+	vcdClient := meta.(*VCDClient)
+
+	name := d.Get("name").(string)
+	providerVdc, err := govcd.GetProviderVdcByName(vcdClient.VCDClient, name)
+	if err != nil {
+		return fmt.Errorf("Could not get provider vdc: %s with error %v", name, err)
+	}
+	if providerVdc == (govcd.ProviderVdc{}) {
+		return fmt.Errorf("Could not find provider vdc: %s", name)
+	}
+
+	p := providerVdc.ProviderVdc
+	d.SetId(p.ID)
+	d.Set("description", p.Description)
+	d.Set("href", p.HREF)
+	d.Set("status", types.VDCStatuses[p.Status])
+	d.Set("is_enabled", p.IsEnabled)
+
+	storageProfiles := make([]string, 0)
+	if p.StorageProfiles != nil {
+		for _, profile := range p.StorageProfiles.ProviderVdcStorageProfile {
+			storageProfiles = append(storageProfiles, profile.Name)
+		}
+	}
+	d.Set("storage_profiles", storageProfiles)
+
+	if p.ComputeCapacity != nil {
+		d.Set("cpu_capacity", flattenProviderVdcCapacity(p.ComputeCapacity.CPU))
+		d.Set("memory_capacity", flattenProviderVdcCapacity(p.ComputeCapacity.Memory))
+	}
+	d.Set("storage_capacity", flattenProviderVdcCapacity(p.StorageCapacity))
+
+	return nil
+}
+
+func flattenProviderVdcCapacity(capacity *types.ProviderVdcCapacity) []map[string]interface{} {
+	if capacity == nil {
+		return nil
+	}
+	return []map[string]interface{}{
+		{
+			"units":      capacity.Units,
+			"allocation": int(capacity.Allocation),
+			"reserved":   int(capacity.Reserved),
+			"total":      int(capacity.Total),
+			"used":       int(capacity.Used),
+			"overhead":   int(capacity.Overhead),
+		},
+	}
+}
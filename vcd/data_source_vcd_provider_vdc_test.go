@@ -0,0 +1,41 @@
+package vcd
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccVcdProviderVdcDataSource_Basic(t *testing.T) {
+	providerVdc := os.Getenv("VCD_PROVIDER_VDC")
+	if providerVdc == "" {
+		t.Skip("Environment variable VCD_PROVIDER_VDC must be set to run provider vdc tests")
+		return
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckVcdProviderVdcDataSource_basic, providerVdc),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"data.vcd_provider_vdc.pvdc", "name", providerVdc),
+					resource.TestCheckResourceAttrSet(
+						"data.vcd_provider_vdc.pvdc", "href"),
+					resource.TestCheckResourceAttr(
+						"data.vcd_provider_vdc.pvdc", "cpu_capacity.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+const testAccCheckVcdProviderVdcDataSource_basic = `
+data "vcd_provider_vdc" "pvdc" {
+  name = "%s"
+}
+`
@@ -0,0 +1,133 @@
+package vcd
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/hashcode"
+	"github.com/hashicorp/terraform/helper/schema"
+	govcd "github.com/vmware/go-vcloud-director/govcd"
+)
+
+func datasourceVcdQuery() *schema.Resource {
+	return &schema.Resource{
+		Read: datasourceVcdQueryRead,
+
+		Schema: map[string]*schema.Schema{
+			"type": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The record type to query, such as vm, vApp or orgVdcNetwork",
+			},
+
+			"filter": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "FIQL conditions the records must match, such as status==POWERED_OFF;name==web*",
+			},
+
+			"sort_asc": &schema.Schema{
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"sort_desc"},
+			},
+
+			"sort_desc": &schema.Schema{
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"sort_asc"},
+			},
+
+			"page_size": &schema.Schema{
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Records fetched per request. All pages are read whatever the size.",
+			},
+
+			"records": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeMap},
+			},
+		},
+	}
+}
+
+func datasourceVcdQueryRead(d *schema.ResourceData, meta interface{}) error {
+	vcdClient := meta.(*VCDClient)
+
+	params := govcd.QueryParams{
+		Type:     d.Get("type").(string),
+		Filter:   d.Get("filter").(string),
+		SortAsc:  d.Get("sort_asc").(string),
+		SortDesc: d.Get("sort_desc").(string),
+		PageSize: d.Get("page_size").(int),
+	}
+	results, err := vcdClient.QueryRecords(params)
+	if err != nil {
+		return fmt.Errorf("error querying %s records: %s", params.Type, err)
+	}
+
+	records := make([]map[string]interface{}, 0, len(results.Records))
+	for _, record := range results.Records {
+		attributes := make(map[string]interface{})
+		for _, attr := range record.Attributes {
+			// Skips namespace declarations
+			if attr.Name.Space == "" && attr.Name.Local != "xmlns" {
+				attributes[attr.Name.Local] = attr.Value
+			}
+		}
+		records = append(records, attributes)
+	}
+
+	d.SetId(queryID(params.Type, params.Filter, params.SortAsc, params.SortDesc))
+	return d.Set("records", records)
+}
+
+// queryFilter joins FIQL conditions with ;, their AND, leaving out empty
+// ones. Each condition is put in parentheses, so that one that is itself
+// an OR with , is ANDed as a whole.
+func queryFilter(conditions ...string) string {
+	var set []string
+	for _, condition := range conditions {
+		if condition != "" {
+			set = append(set, "("+condition+")")
+		}
+	}
+	return strings.Join(set, ";")
+}
+
+// metadataConditions returns the FIQL conditions matching records with
+// the given string metadata values.
+func metadataConditions(metadata map[string]interface{}) string {
+	keys := make([]string, 0, len(metadata))
+	for key := range metadata {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	conditions := make([]string, 0, len(keys))
+	for _, key := range keys {
+		conditions = append(conditions, fmt.Sprintf("metadata:%s==STRING:%s", key, metadata[key].(string)))
+	}
+	return queryFilter(conditions...)
+}
+
+// vdcCondition returns the FIQL condition matching the records in the
+// VDC of a data source, if it or the provider sets one.
+func vdcCondition(vcdClient *VCDClient, d *schema.ResourceData) (string, error) {
+	if vcdClient.vdcName(d) == "" {
+		return "", nil
+	}
+	_, vdc, err := vcdClient.getOrgAndVdc(d)
+	if err != nil {
+		return "", err
+	}
+	return "vdc==" + vdc.Vdc.HREF, nil
+}
+
+// Returns a stable ID for the data source listing the results of a query.
+func queryID(parts ...string) string {
+	return strconv.Itoa(hashcode.String(strings.Join(parts, "\n")))
+}
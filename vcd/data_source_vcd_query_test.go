@@ -0,0 +1,55 @@
+package vcd
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccVcdQueryDataSource_Paging(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccMockObjects(t, map[string][]string{
+				"tf-acc-query-1": nil,
+				"tf-acc-query-2": nil,
+				"tf-acc-query-3": nil,
+			}, nil)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccCheckVcdQueryDataSource_paging,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"data.vcd_query.test", "records.#", "3"),
+					resource.TestCheckResourceAttr(
+						"data.vcd_query.test", "records.0.name", "tf-acc-query-3"),
+					resource.TestCheckResourceAttr(
+						"data.vcd_query.test", "records.2.name", "tf-acc-query-1"),
+					resource.TestCheckResourceAttr(
+						"data.vcd_query.test", "records.2.status", "POWERED_OFF"),
+				),
+			},
+		},
+	})
+}
+
+func TestQueryFilter(t *testing.T) {
+	got := queryFilter("name==a*,name==b*", "", "status==POWERED_ON")
+	if want := "(name==a*,name==b*);(status==POWERED_ON)"; got != want {
+		t.Errorf("queryFilter = %q, want %q", got, want)
+	}
+	if got := queryFilter("", ""); got != "" {
+		t.Errorf("queryFilter of empty conditions = %q, want none", got)
+	}
+}
+
+// One record per page, so that every page has to be followed
+const testAccCheckVcdQueryDataSource_paging = `
+data "vcd_query" "test" {
+  type      = "vApp"
+  filter    = "name==tf-acc-query-*"
+  sort_desc = "name"
+  page_size = 1
+}
+`
@@ -0,0 +1,71 @@
+package vcd
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func datasourceVcdRight() *schema.Resource {
+	return &schema.Resource{
+		Read: datasourceVcdRightRead,
+
+		Schema: map[string]*schema.Schema{
+			"org": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"description": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"category": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"right_type": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"href": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func datasourceVcdRightRead(d *schema.ResourceData, meta interface{}) error {
+	vcdClient := meta.(*VCDClient)
+
+	adminOrg, err := getAdminOrg(vcdClient, vcdClient.orgName(d))
+	if err != nil {
+		return err
+	}
+
+	name := d.Get("name").(string)
+	right, err := adminOrg.GetRightByName(name)
+	if err != nil {
+		return fmt.Errorf("Could not get right: %s with error %v", name, err)
+	}
+	if right == nil {
+		return fmt.Errorf("Could not find right: %s", name)
+	}
+
+	d.SetId(right.ID)
+	d.Set("description", right.Description)
+	d.Set("category", right.Category)
+	d.Set("right_type", right.RightType)
+	d.Set("href", right.HREF)
+
+	return nil
+}
@@ -0,0 +1,175 @@
+package vcd
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	types "github.com/vmware/go-vcloud-director/types/v56"
+)
+
+func datasourceVcdVApp() *schema.Resource {
+	return &schema.Resource{
+		Read: datasourceVcdVAppRead,
+
+		Schema: map[string]*schema.Schema{
+			"org": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"vdc": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"href": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"description": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"status": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"deployed": &schema.Schema{
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+
+			"vms": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"href": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"status": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+
+			"networks": &schema.Schema{
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Names of the networks the vApp connects its VMs to",
+			},
+
+			"metadata": &schema.Schema{
+				Type:     schema.TypeMap,
+				Computed: true,
+			},
+
+			"lease": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"deployment_lease_in_sec": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"deployment_lease_expiration": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"storage_lease_in_sec": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"storage_lease_expiration": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func datasourceVcdVAppRead(d *schema.ResourceData, meta interface{}) error {
+	vcdClient := meta.(*VCDClient)
+
+	_, vdc, err := vcdClient.getOrgAndVdc(d)
+	if err != nil {
+		return err
+	}
+
+	vapp, err := vdc.FindVAppByName(d.Get("name").(string))
+	if err != nil {
+		return fmt.Errorf("error finding vapp: %s", err)
+	}
+
+	metadata, err := vapp.GetMetadata()
+	if err != nil {
+		return fmt.Errorf("error getting metadata of vapp %s: %s", vapp.VApp.Name, err)
+	}
+
+	vms := make([]map[string]interface{}, 0)
+	if vapp.VApp.Children != nil {
+		for _, vm := range vapp.VApp.Children.VM {
+			vms = append(vms, map[string]interface{}{
+				"name":   vm.Name,
+				"href":   vm.HREF,
+				"status": types.VAppStatuses[vm.Status],
+			})
+		}
+	}
+
+	networks := make([]string, 0)
+	if networkConfig := vapp.VApp.NetworkConfigSection; networkConfig != nil {
+		for _, network := range networkConfig.NetworkConfig {
+			// vCD lists the none network, which disconnected NICs are on
+			if network.NetworkName != "none" {
+				networks = append(networks, network.NetworkName)
+			}
+		}
+	}
+
+	lease := make([]map[string]interface{}, 0, 1)
+	if leaseSettings := vapp.VApp.LeaseSettingsSection; leaseSettings != nil {
+		lease = append(lease, map[string]interface{}{
+			"deployment_lease_in_sec":     leaseSettings.DeploymentLeaseInSeconds,
+			"deployment_lease_expiration": leaseSettings.DeploymentLeaseExpiration,
+			"storage_lease_in_sec":        leaseSettings.StorageLeaseInSeconds,
+			"storage_lease_expiration":    leaseSettings.StorageLeaseExpiration,
+		})
+	}
+
+	d.SetId(vapp.VApp.HREF)
+	d.Set("href", vapp.VApp.HREF)
+	d.Set("description", vapp.VApp.Description)
+	d.Set("status", types.VAppStatuses[vapp.VApp.Status])
+	d.Set("deployed", vapp.VApp.Deployed)
+	if err := d.Set("vms", vms); err != nil {
+		return err
+	}
+	if err := d.Set("networks", networks); err != nil {
+		return err
+	}
+	if err := d.Set("metadata", flattenMetadata(metadata)); err != nil {
+		return err
+	}
+	return d.Set("lease", lease)
+}
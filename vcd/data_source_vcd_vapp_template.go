@@ -0,0 +1,217 @@
+package vcd
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	govcd "github.com/vmware/go-vcloud-director/govcd"
+	types "github.com/vmware/go-vcloud-director/types/v56"
+)
+
+func datasourceVcdVAppTemplate() *schema.Resource {
+	return &schema.Resource{
+		Read: datasourceVcdVAppTemplateRead,
+
+		Schema: map[string]*schema.Schema{
+			"org": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"catalog_name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"name": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the catalog item of the template",
+			},
+
+			"href": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"description": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"has_eula": &schema.Schema{
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "True if the template or one of its VMs has a license to accept with accept_all_eulas",
+			},
+
+			"eulas": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"vms": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"href": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"cpus": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"cores_per_socket": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"memory": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"guest_os": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"os_type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"nics":  datasourceVmNicsSchema(),
+						"disks": datasourceVmDisksSchema(),
+						"ovf_properties": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"key": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"label": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"description": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"type": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"default_value": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"user_configurable": {
+										Type:     schema.TypeBool,
+										Computed: true,
+									},
+								},
+							},
+						},
+						"has_eula": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func datasourceVcdVAppTemplateRead(d *schema.ResourceData, meta interface{}) error {
+	vcdClient := meta.(*VCDClient)
+
+	org, err := vcdClient.getOrgFromResource(d)
+	if err != nil {
+		return err
+	}
+
+	catalogName := d.Get("catalog_name").(string)
+	catalog, err := vcdClient.getCatalog(org, catalogName)
+	if err != nil {
+		return fmt.Errorf("error finding catalog %s: %s", catalogName, err)
+	}
+	if catalog == (govcd.Catalog{}) {
+		return fmt.Errorf("could not find catalog %s", catalogName)
+	}
+
+	catalogItem, err := catalog.FindCatalogItem(d.Get("name").(string))
+	if err != nil {
+		return fmt.Errorf("error finding catalog item: %s", err)
+	}
+
+	template, err := catalogItem.GetVAppTemplate()
+	if err != nil {
+		return fmt.Errorf("error finding vapp template: %s", err)
+	}
+
+	eulas := flattenEulas(template.VAppTemplate.EulaSection)
+	vms := make([]map[string]interface{}, 0)
+	if template.VAppTemplate.Children != nil {
+		for _, vm := range template.VAppTemplate.Children.VM {
+			vmEulas := flattenEulas(vm.EulaSection)
+			eulas = append(eulas, vmEulas...)
+
+			nics, _ := flattenNetworkConnections(vm.NetworkConnectionSection)
+			flattened := flattenVirtualHardware(vm.VirtualHardwareSection)
+			for key, value := range flattenOperatingSystem(vm.OperatingSystemSection) {
+				flattened[key] = value
+			}
+			flattened["name"] = vm.Name
+			flattened["href"] = vm.HREF
+			flattened["nics"] = nics
+			flattened["ovf_properties"] = flattenOvfProperties(vm.ProductSection)
+			flattened["has_eula"] = len(vmEulas) > 0
+			vms = append(vms, flattened)
+		}
+	}
+
+	d.SetId(template.VAppTemplate.HREF)
+	d.Set("href", template.VAppTemplate.HREF)
+	d.Set("description", template.VAppTemplate.Description)
+	d.Set("has_eula", len(eulas) > 0)
+	if err := d.Set("eulas", eulas); err != nil {
+		return err
+	}
+	return d.Set("vms", vms)
+}
+
+// Returns the license texts of EULA sections.
+func flattenEulas(sections []*types.EulaSection) []string {
+	eulas := make([]string, 0, len(sections))
+	for _, section := range sections {
+		eulas = append(eulas, section.License)
+	}
+	return eulas
+}
+
+// flattenOvfProperties returns the properties of the product section of
+// a VM, which the ovf argument of vcd_vapp sets.
+func flattenOvfProperties(section *types.ProductSection) []map[string]interface{} {
+	properties := make([]map[string]interface{}, 0)
+	if section == nil {
+		return properties
+	}
+	for _, property := range section.Property {
+		properties = append(properties, map[string]interface{}{
+			"key":               property.Key,
+			"label":             property.Label,
+			"description":       property.Description,
+			"type":              property.Type,
+			"default_value":     property.DefaultValue,
+			"user_configurable": property.UserConfigurable,
+		})
+	}
+	return properties
+}
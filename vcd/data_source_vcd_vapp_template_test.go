@@ -0,0 +1,74 @@
+package vcd
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	types "github.com/vmware/go-vcloud-director/types/v56"
+)
+
+func TestAccVcdVAppTemplateDataSource_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccMockObjects(t, nil, map[string][]string{
+				"tf-acc-template": {"tf-acc-template-web", "tf-acc-template-db"},
+			})
+			template := testMockVCD.addVAppTemplate("tf-acc-template-eula", "tf-acc-template-eula-vm")
+			testMockVCD.lock.Lock()
+			template.Children.VM[0].EulaSection = []*types.EulaSection{{License: "You agree"}}
+			testMockVCD.lock.Unlock()
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckVcdVAppTemplateDataSource_basic, testOrg, mockVCDCatalog, mockVCDCatalog),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(
+						"data.vcd_vapp_template.test", "href"),
+					resource.TestCheckResourceAttr(
+						"data.vcd_vapp_template.test", "has_eula", "false"),
+					resource.TestCheckResourceAttr(
+						"data.vcd_vapp_template.test", "vms.#", "2"),
+					resource.TestCheckResourceAttr(
+						"data.vcd_vapp_template.test", "vms.1.name", "tf-acc-template-db"),
+					resource.TestCheckResourceAttr(
+						"data.vcd_vapp_template.test", "vms.1.cpus", "2"),
+					resource.TestCheckResourceAttr(
+						"data.vcd_vapp_template.test", "vms.1.memory", "1024"),
+					resource.TestCheckResourceAttr(
+						"data.vcd_vapp_template.test", "vms.1.os_type", "ubuntu64Guest"),
+					resource.TestCheckResourceAttr(
+						"data.vcd_vapp_template.test", "vms.1.nics.#", "1"),
+					resource.TestCheckResourceAttr(
+						"data.vcd_vapp_template.test", "vms.1.nics.0.ip_allocation_mode", "POOL"),
+					resource.TestCheckResourceAttr(
+						"data.vcd_vapp_template.test", "vms.1.disks.0.size", "16384"),
+					resource.TestCheckResourceAttr(
+						"data.vcd_vapp_template.test", "vms.1.ovf_properties.0.key", "hostname"),
+					resource.TestCheckResourceAttr(
+						"data.vcd_vapp_template.test", "vms.1.ovf_properties.0.user_configurable", "true"),
+					resource.TestCheckResourceAttr(
+						"data.vcd_vapp_template.eula", "has_eula", "true"),
+					resource.TestCheckResourceAttr(
+						"data.vcd_vapp_template.eula", "vms.0.has_eula", "true"),
+					resource.TestCheckResourceAttr(
+						"data.vcd_vapp_template.eula", "eulas.0", "You agree"),
+				),
+			},
+		},
+	})
+}
+
+const testAccCheckVcdVAppTemplateDataSource_basic = `
+data "vcd_vapp_template" "test" {
+  org          = "%s"
+  catalog_name = "%s"
+  name         = "tf-acc-template"
+}
+
+data "vcd_vapp_template" "eula" {
+  catalog_name = "%s"
+  name         = "tf-acc-template-eula"
+}
+`
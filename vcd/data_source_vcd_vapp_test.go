@@ -0,0 +1,53 @@
+package vcd
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccVcdVAppDataSource_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccMockObjects(t, nil, nil)
+			vApp := testMockVCD.addVApp("tf-acc-vapp-ds", "tf-acc-vapp-ds-web", "tf-acc-vapp-ds-db")
+			testMockVCD.setMetadata(vApp.HREF, "team", "networking")
+			testMockVCD.setMetadata(vApp.Children.VM[0].HREF, "role", "web")
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckVcdVAppDataSource_basic, testOrg, testVDC),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(
+						"data.vcd_vapp.test", "href"),
+					resource.TestCheckResourceAttr(
+						"data.vcd_vapp.test", "status", "POWERED_OFF"),
+					resource.TestCheckResourceAttr(
+						"data.vcd_vapp.test", "vms.#", "2"),
+					resource.TestCheckResourceAttr(
+						"data.vcd_vapp.test", "vms.1.name", "tf-acc-vapp-ds-db"),
+					resource.TestCheckResourceAttr(
+						"data.vcd_vapp.test", "networks.#", "1"),
+					resource.TestCheckResourceAttr(
+						"data.vcd_vapp.test", "networks.0", mockVCDVAppNetwork),
+					resource.TestCheckResourceAttr(
+						"data.vcd_vapp.test", "metadata.%", "1"),
+					resource.TestCheckResourceAttr(
+						"data.vcd_vapp.test", "metadata.team", "networking"),
+					resource.TestCheckResourceAttr(
+						"data.vcd_vapp.test", "lease.0.storage_lease_in_sec", "7776000"),
+				),
+			},
+		},
+	})
+}
+
+const testAccCheckVcdVAppDataSource_basic = `
+data "vcd_vapp" "test" {
+  org  = "%s"
+  vdc  = "%s"
+  name = "tf-acc-vapp-ds"
+}
+`
@@ -0,0 +1,296 @@
+package vcd
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	types "github.com/vmware/go-vcloud-director/types/v56"
+)
+
+// ResourceType of the items of a VirtualHardwareSection
+const (
+	hardwareItemCPU    = 3
+	hardwareItemMemory = 4
+	hardwareItemDisk   = 17
+)
+
+func datasourceVcdVAppVm() *schema.Resource {
+	return &schema.Resource{
+		Read: datasourceVcdVAppVmRead,
+
+		Schema: map[string]*schema.Schema{
+			"org": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"vdc": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"vapp_name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"href": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"description": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"status": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"deployed": &schema.Schema{
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+
+			"cpus": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"cores_per_socket": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"memory": &schema.Schema{
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Memory in MB",
+			},
+
+			"guest_os": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Name of the guest OS, such as Ubuntu Linux (64-bit)",
+			},
+
+			"os_type": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "vSphere identifier of the guest OS, such as ubuntu64Guest",
+			},
+
+			"ip": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "IP address of the primary NIC",
+			},
+
+			"nics": datasourceVmNicsSchema(),
+
+			"disks": datasourceVmDisksSchema(),
+
+			"metadata": &schema.Schema{
+				Type:     schema.TypeMap,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func datasourceVcdVAppVmRead(d *schema.ResourceData, meta interface{}) error {
+	vcdClient := meta.(*VCDClient)
+
+	_, vdc, err := vcdClient.getOrgAndVdc(d)
+	if err != nil {
+		return err
+	}
+
+	vapp, err := vdc.FindVAppByName(d.Get("vapp_name").(string))
+	if err != nil {
+		return fmt.Errorf("error finding vapp: %s", err)
+	}
+
+	vm, err := vdc.FindVMByName(vapp, d.Get("name").(string))
+	if err != nil {
+		return fmt.Errorf("error finding vm: %s", err)
+	}
+
+	metadata, err := vm.GetMetadata()
+	if err != nil {
+		return fmt.Errorf("error getting metadata of vm %s: %s", vm.VM.Name, err)
+	}
+
+	d.SetId(vm.VM.HREF)
+	d.Set("href", vm.VM.HREF)
+	d.Set("description", vm.VM.Description)
+	d.Set("status", types.VAppStatuses[vm.VM.Status])
+	d.Set("deployed", vm.VM.Deployed)
+	nics, ip := flattenNetworkConnections(vm.VM.NetworkConnectionSection)
+	d.Set("ip", ip)
+	if err := d.Set("nics", nics); err != nil {
+		return err
+	}
+	hardware := flattenVirtualHardware(vm.VM.VirtualHardwareSection)
+	for key, value := range flattenOperatingSystem(vm.VM.OperatingSystemSection) {
+		hardware[key] = value
+	}
+	for key, value := range hardware {
+		if err := d.Set(key, value); err != nil {
+			return err
+		}
+	}
+	return d.Set("metadata", flattenMetadata(metadata))
+}
+
+func datasourceVmNicsSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Computed: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"index": {
+					Type:     schema.TypeInt,
+					Computed: true,
+				},
+				"network": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"ip": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"mac": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"ip_allocation_mode": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"is_connected": {
+					Type:     schema.TypeBool,
+					Computed: true,
+				},
+				"primary": {
+					Type:     schema.TypeBool,
+					Computed: true,
+				},
+			},
+		},
+	}
+}
+
+func datasourceVmDisksSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Computed: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"name": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"size": {
+					Type:        schema.TypeInt,
+					Computed:    true,
+					Description: "Size in MB",
+				},
+				"bus_type": {
+					Type:     schema.TypeInt,
+					Computed: true,
+				},
+				"bus_sub_type": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"unit_number": {
+					Type:     schema.TypeInt,
+					Computed: true,
+				},
+			},
+		},
+	}
+}
+
+// flattenVirtualHardware returns the cpus, cores_per_socket, memory and
+// disks of a VM from its hardware section.
+func flattenVirtualHardware(section *types.VirtualHardwareSection) map[string]interface{} {
+	var cpus, coresPerSocket, memory int
+	disks := make([]map[string]interface{}, 0)
+	if section != nil {
+		for _, item := range section.Item {
+			switch item.ResourceType {
+			case hardwareItemCPU:
+				cpus = item.VirtualQuantity
+				coresPerSocket = item.CoresPerSocket
+			case hardwareItemMemory:
+				memory = item.VirtualQuantity
+			case hardwareItemDisk:
+				disk := map[string]interface{}{
+					"name":        item.ElementName,
+					"unit_number": item.AddressOnParent,
+				}
+				if len(item.HostResource) > 0 {
+					disk["size"] = item.HostResource[0].Capacity
+					disk["bus_type"] = item.HostResource[0].BusType
+					disk["bus_sub_type"] = item.HostResource[0].BusSubType
+				}
+				disks = append(disks, disk)
+			}
+		}
+	}
+	return map[string]interface{}{
+		"cpus":             cpus,
+		"cores_per_socket": coresPerSocket,
+		"memory":           memory,
+		"disks":            disks,
+	}
+}
+
+// flattenNetworkConnections returns the nics of a VM, and the IP address
+// of its primary NIC.
+func flattenNetworkConnections(section *types.NetworkConnectionSection) ([]map[string]interface{}, string) {
+	var ip string
+	nics := make([]map[string]interface{}, 0)
+	if section == nil {
+		return nics, ip
+	}
+	for _, nic := range section.NetworkConnection {
+		primary := nic.NetworkConnectionIndex == section.PrimaryNetworkConnectionIndex
+		if primary {
+			ip = nic.IPAddress
+		}
+		nics = append(nics, map[string]interface{}{
+			"index":              nic.NetworkConnectionIndex,
+			"network":            nic.Network,
+			"ip":                 nic.IPAddress,
+			"mac":                nic.MACAddress,
+			"ip_allocation_mode": nic.IPAddressAllocationMode,
+			"is_connected":       nic.IsConnected,
+			"primary":            primary,
+		})
+	}
+	return nics, ip
+}
+
+// flattenOperatingSystem returns the guest_os and os_type of a VM.
+func flattenOperatingSystem(section *types.OperatingSystemSection) map[string]interface{} {
+	var guestOS, osType string
+	if section != nil {
+		guestOS = section.Description
+		osType = section.OsType
+	}
+	return map[string]interface{}{
+		"guest_os": guestOS,
+		"os_type":  osType,
+	}
+}
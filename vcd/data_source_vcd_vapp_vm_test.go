@@ -0,0 +1,63 @@
+package vcd
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccVcdVAppVmDataSource_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccMockObjects(t, nil, nil)
+			vApp := testMockVCD.addVApp("tf-acc-vapp-vm-ds", "tf-acc-vapp-vm-ds-web")
+			testMockVCD.setMetadata(vApp.Children.VM[0].HREF, "role", "web")
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckVcdVAppVmDataSource_basic, testOrg, testVDC),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(
+						"data.vcd_vapp_vm.test", "href"),
+					resource.TestCheckResourceAttr(
+						"data.vcd_vapp_vm.test", "status", "POWERED_OFF"),
+					resource.TestCheckResourceAttr(
+						"data.vcd_vapp_vm.test", "cpus", "2"),
+					resource.TestCheckResourceAttr(
+						"data.vcd_vapp_vm.test", "memory", "1024"),
+					resource.TestCheckResourceAttr(
+						"data.vcd_vapp_vm.test", "guest_os", "Ubuntu Linux (64-bit)"),
+					resource.TestCheckResourceAttr(
+						"data.vcd_vapp_vm.test", "os_type", "ubuntu64Guest"),
+					resource.TestCheckResourceAttr(
+						"data.vcd_vapp_vm.test", "ip", "10.10.102.10"),
+					resource.TestCheckResourceAttr(
+						"data.vcd_vapp_vm.test", "nics.#", "1"),
+					resource.TestCheckResourceAttr(
+						"data.vcd_vapp_vm.test", "nics.0.network", mockVCDVAppNetwork),
+					resource.TestCheckResourceAttr(
+						"data.vcd_vapp_vm.test", "nics.0.mac", "00:50:56:01:00:00"),
+					resource.TestCheckResourceAttr(
+						"data.vcd_vapp_vm.test", "nics.0.primary", "true"),
+					resource.TestCheckResourceAttr(
+						"data.vcd_vapp_vm.test", "disks.#", "1"),
+					resource.TestCheckResourceAttr(
+						"data.vcd_vapp_vm.test", "disks.0.size", "16384"),
+					resource.TestCheckResourceAttr(
+						"data.vcd_vapp_vm.test", "metadata.role", "web"),
+				),
+			},
+		},
+	})
+}
+
+const testAccCheckVcdVAppVmDataSource_basic = `
+data "vcd_vapp_vm" "test" {
+  org       = "%s"
+  vdc       = "%s"
+  vapp_name = "tf-acc-vapp-vm-ds"
+  name      = "tf-acc-vapp-vm-ds-web"
+}
+`
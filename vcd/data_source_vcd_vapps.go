@@ -0,0 +1,129 @@
+package vcd
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	govcd "github.com/vmware/go-vcloud-director/govcd"
+)
+
+func datasourceVcdVApps() *schema.Resource {
+	return &schema.Resource{
+		Read: datasourceVcdVAppsRead,
+
+		Schema: map[string]*schema.Schema{
+			"org": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"vdc": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"status": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Status the vApps must be in, such as POWERED_OFF",
+			},
+
+			"filter": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "FIQL conditions on vApp records the vApps must match, such as name==web*",
+			},
+
+			"metadata": &schema.Schema{
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Description: "String metadata values the vApps must have",
+			},
+
+			"vapps": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"href": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"status": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"deployed": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"vdc_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"owner_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"number_of_vms": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"creation_date": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func datasourceVcdVAppsRead(d *schema.ResourceData, meta interface{}) error {
+	vcdClient := meta.(*VCDClient)
+
+	vdc, err := vdcCondition(vcdClient, d)
+	if err != nil {
+		return err
+	}
+	status := ""
+	if v, ok := d.GetOk("status"); ok {
+		status = "status==" + v.(string)
+	}
+	filter := queryFilter(
+		vdc,
+		status,
+		metadataConditions(d.Get("metadata").(map[string]interface{})),
+		d.Get("filter").(string),
+	)
+	results, err := vcdClient.QueryRecords(govcd.QueryParams{
+		Type:    "vApp",
+		Filter:  filter,
+		SortAsc: "name",
+	})
+	if err != nil {
+		return fmt.Errorf("error querying vApps: %s", err)
+	}
+
+	vApps := make([]map[string]interface{}, 0, len(results.Results.VAppRecord))
+	for _, vApp := range results.Results.VAppRecord {
+		vApps = append(vApps, map[string]interface{}{
+			"name":          vApp.Name,
+			"href":          vApp.HREF,
+			"status":        vApp.Status,
+			"deployed":      vApp.Deployed,
+			"vdc_name":      vApp.VdcName,
+			"owner_name":    vApp.OwnerName,
+			"number_of_vms": vApp.NumberOfVMs,
+			"creation_date": vApp.CreationDate,
+		})
+	}
+
+	d.SetId(queryID("vApp", filter))
+	return d.Set("vapps", vApps)
+}
@@ -0,0 +1,92 @@
+package vcd
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccVcdVAppsDataSource_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccMockObjects(t, map[string][]string{
+				"tf-acc-vapps-1": {"tf-acc-vapps-vm"},
+				"tf-acc-vapps-2": {"tf-acc-vapps-vm", "tf-acc-vapps-vm2"},
+			}, nil)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckVcdVAppsDataSource_basic, testOrg, testVDC),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"data.vcd_vapps.test", "vapps.#", "2"),
+					resource.TestCheckResourceAttr(
+						"data.vcd_vapps.test", "vapps.0.name", "tf-acc-vapps-1"),
+					resource.TestCheckResourceAttr(
+						"data.vcd_vapps.test", "vapps.1.name", "tf-acc-vapps-2"),
+					resource.TestCheckResourceAttr(
+						"data.vcd_vapps.test", "vapps.1.number_of_vms", "2"),
+					resource.TestCheckResourceAttr(
+						"data.vcd_vapps.test", "vapps.1.status", "POWERED_OFF"),
+					resource.TestCheckResourceAttr(
+						"data.vcd_vapps.on", "vapps.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+const testAccCheckVcdVAppsDataSource_basic = `
+data "vcd_vapps" "test" {
+  org    = "%s"
+  vdc    = "%s"
+  filter = "name==tf-acc-vapps-*"
+}
+
+data "vcd_vapps" "on" {
+  status = "POWERED_ON"
+  filter = "name==tf-acc-vapps-*"
+}
+`
+
+// Builds the vApp it lists with vcd_vapp instead of adding it to the mock
+// vCD, so that it runs against vCD as well.
+func TestAccVcdVAppsDataSource_Instantiated(t *testing.T) {
+	catalog, template := testAccVAppTemplate(t, "tf-acc-vapps-template")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckVcdVAppDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckVcdVAppsDataSource_instantiated, testOrg, testVDC, catalog, template),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"data.vcd_vapps.test", "vapps.#", "1"),
+					resource.TestCheckResourceAttr(
+						"data.vcd_vapps.test", "vapps.0.name", "tf-acc-vapps-instantiated"),
+					resource.TestCheckResourceAttr(
+						"data.vcd_vapps.test", "vapps.0.status", "POWERED_ON"),
+				),
+			},
+		},
+	})
+}
+
+const testAccCheckVcdVAppsDataSource_instantiated = `
+resource "vcd_vapp" "instantiated" {
+  org           = "%s"
+  vdc           = "%s"
+  name          = "tf-acc-vapps-instantiated"
+  catalog_name  = "%s"
+  template_name = "%s"
+  instantiate   = true
+}
+
+data "vcd_vapps" "test" {
+  filter = "name==${vcd_vapp.instantiated.name}"
+}
+`
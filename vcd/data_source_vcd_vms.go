@@ -0,0 +1,130 @@
+package vcd
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	govcd "github.com/vmware/go-vcloud-director/govcd"
+)
+
+func datasourceVcdVms() *schema.Resource {
+	return &schema.Resource{
+		Read: datasourceVcdVmsRead,
+
+		Schema: map[string]*schema.Schema{
+			"org": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"vdc": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"filter": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "FIQL conditions on vm records the VMs must match, such as name==web*",
+			},
+
+			"metadata": &schema.Schema{
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Description: "String metadata values the VMs must have",
+			},
+
+			"vms": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"href": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"status": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"deployed": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"vapp_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"vapp_href": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"guest_os": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"cpus": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"memory": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"network_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func datasourceVcdVmsRead(d *schema.ResourceData, meta interface{}) error {
+	vcdClient := meta.(*VCDClient)
+
+	vdc, err := vdcCondition(vcdClient, d)
+	if err != nil {
+		return err
+	}
+	filter := queryFilter(
+		// VMs of vApp templates are vm records too
+		"isVAppTemplate==false",
+		vdc,
+		metadataConditions(d.Get("metadata").(map[string]interface{})),
+		d.Get("filter").(string),
+	)
+	results, err := vcdClient.QueryRecords(govcd.QueryParams{
+		Type:    "vm",
+		Filter:  filter,
+		SortAsc: "name",
+	})
+	if err != nil {
+		return fmt.Errorf("error querying VMs: %s", err)
+	}
+
+	vms := make([]map[string]interface{}, 0, len(results.Results.VMRecord))
+	for _, vm := range results.Results.VMRecord {
+		vms = append(vms, map[string]interface{}{
+			"name":         vm.Name,
+			"href":         vm.HREF,
+			"status":       vm.Status,
+			"deployed":     vm.Deployed,
+			"vapp_name":    vm.VAppParentName,
+			"vapp_href":    vm.VAppParentHREF,
+			"guest_os":     vm.GuestOS,
+			"cpus":         vm.Cpus,
+			"memory":       vm.MemoryMB,
+			"network_name": vm.NetworkName,
+		})
+	}
+
+	d.SetId(queryID("vm", filter))
+	return d.Set("vms", vms)
+}
@@ -0,0 +1,50 @@
+package vcd
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccVcdVmsDataSource_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccMockObjects(t, map[string][]string{
+				"tf-acc-vms": {"tf-acc-vms-web", "tf-acc-vms-db"},
+			}, nil)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckVcdVmsDataSource_basic, testOrg, testVDC),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"data.vcd_vms.test", "vms.#", "2"),
+					resource.TestCheckResourceAttr(
+						"data.vcd_vms.test", "vms.0.name", "tf-acc-vms-db"),
+					resource.TestCheckResourceAttr(
+						"data.vcd_vms.test", "vms.0.vapp_name", "tf-acc-vms"),
+					resource.TestCheckResourceAttrSet(
+						"data.vcd_vms.test", "vms.0.href"),
+					resource.TestCheckResourceAttr(
+						"data.vcd_vms.web", "vms.#", "1"),
+					resource.TestCheckResourceAttr(
+						"data.vcd_vms.web", "vms.0.name", "tf-acc-vms-web"),
+				),
+			},
+		},
+	})
+}
+
+const testAccCheckVcdVmsDataSource_basic = `
+data "vcd_vms" "test" {
+  org    = "%s"
+  vdc    = "%s"
+  filter = "containerName==tf-acc-vms"
+}
+
+data "vcd_vms" "web" {
+  filter = "name==tf-acc-vms-web"
+}
+`
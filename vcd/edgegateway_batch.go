@@ -0,0 +1,155 @@
+package vcd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	govcd "github.com/vmware/go-vcloud-director/govcd"
+	types "github.com/vmware/go-vcloud-director/types/v56"
+)
+
+// Every configureServices call redeploys the edge gateway, which takes a
+// while, so the changes resources make to the services of a gateway are
+// queued for edgeGatewayBatchWindow and submitted together.
+const edgeGatewayBatchWindow = 2 * time.Second
+
+// edgeGatewayChange makes a change to config, the services configuration
+// to submit for edgeGateway. A service config doesn't have yet must start
+// from the current configuration of the gateway.
+type edgeGatewayChange func(edgeGateway *govcd.EdgeGateway, config *types.EdgeGatewayServiceConfiguration) error
+
+type queuedEdgeGatewayChange struct {
+	ctx    context.Context
+	change edgeGatewayChange
+	result chan error
+}
+
+type edgeGatewayBatch struct {
+	edgeGateway govcd.EdgeGateway
+	changes     []queuedEdgeGatewayChange
+}
+
+// edgeGatewayBatches holds the changes waiting to be submitted, by edge
+// gateway HREF.
+type edgeGatewayBatches struct {
+	lock    sync.Mutex
+	pending map[string]*edgeGatewayBatch
+}
+
+func newEdgeGatewayBatches() *edgeGatewayBatches {
+	return &edgeGatewayBatches{
+		pending: make(map[string]*edgeGatewayBatch),
+	}
+}
+
+// configureEdgeGateway queues change for the edge gateway and waits until
+// it has been submitted, returning its own result.
+func (c *VCDClient) configureEdgeGateway(ctx context.Context, edgeGateway govcd.EdgeGateway, change edgeGatewayChange) error {
+	href := edgeGateway.EdgeGateway.HREF
+	queued := queuedEdgeGatewayChange{
+		ctx:    ctx,
+		change: change,
+		result: make(chan error, 1),
+	}
+
+	c.edgeGatewayBatches.lock.Lock()
+	batch, ok := c.edgeGatewayBatches.pending[href]
+	if !ok {
+		batch = &edgeGatewayBatch{edgeGateway: edgeGateway}
+		c.edgeGatewayBatches.pending[href] = batch
+		time.AfterFunc(edgeGatewayBatchWindow, func() {
+			c.submitEdgeGatewayBatch(href)
+		})
+	}
+	batch.changes = append(batch.changes, queued)
+	c.edgeGatewayBatches.lock.Unlock()
+
+	return <-queued.result
+}
+
+func (c *VCDClient) submitEdgeGatewayBatch(href string) {
+	// Changes queued while another batch is being applied to the gateway
+	// join this batch
+	c.lockEdgeGateway(href)
+	defer c.unlockEdgeGateway(href)
+
+	c.edgeGatewayBatches.lock.Lock()
+	batch := c.edgeGatewayBatches.pending[href]
+	delete(c.edgeGatewayBatches.pending, href)
+	c.edgeGatewayBatches.lock.Unlock()
+
+	edgeGateway := batch.edgeGateway
+	submitted, err := c.submitEdgeGatewayChanges(&edgeGateway, batch.changes)
+
+	// vCD rejects the configuration as a whole, find out which changes
+	// it rejected by submitting them one by one
+	var vcdErr *govcd.VCDError
+	if len(submitted) > 1 && errors.As(err, &vcdErr) && !isTransientError(err) {
+		log.Printf("[INFO] Edge gateway %s rejected %d changes, submitting them one by one: %s",
+			edgeGateway.EdgeGateway.Name, len(submitted), err)
+		for _, queued := range submitted {
+			single, err := c.submitEdgeGatewayChanges(&edgeGateway, []queuedEdgeGatewayChange{queued})
+			if len(single) > 0 {
+				queued.result <- err
+			}
+		}
+		return
+	}
+
+	for _, queued := range submitted {
+		queued.result <- err
+	}
+}
+
+// Makes changes to a fresh copy of the services configuration of the edge
+// gateway and submits them together. Changes that can't be made get their
+// result straight away, the others are returned with the result of the
+// submission.
+func (c *VCDClient) submitEdgeGatewayChanges(edgeGateway *govcd.EdgeGateway, changes []queuedEdgeGatewayChange) ([]queuedEdgeGatewayChange, error) {
+	err := edgeGateway.Refresh()
+	if err != nil {
+		return changes, fmt.Errorf("Error refreshing edge gateway: %w", err)
+	}
+
+	config := &types.EdgeGatewayServiceConfiguration{}
+	var submitted []queuedEdgeGatewayChange
+	for _, queued := range changes {
+		if err := queued.ctx.Err(); err != nil {
+			queued.result <- fmt.Errorf("Timed out waiting for edge gateway %s: %s", edgeGateway.EdgeGateway.Name, err)
+			continue
+		}
+		if err := queued.change(edgeGateway, config); err != nil {
+			queued.result <- err
+			continue
+		}
+		submitted = append(submitted, queued)
+	}
+	if len(submitted) == 0 {
+		return nil, nil
+	}
+
+	// Give the submission as long as the most patient of the resources
+	ctx := submitted[0].ctx
+	for _, queued := range submitted[1:] {
+		latest, _ := ctx.Deadline()
+		if deadline, ok := queued.ctx.Deadline(); ok && deadline.After(latest) {
+			ctx = queued.ctx
+		}
+	}
+
+	log.Printf("[INFO] Reconfiguring edge gateway %s with %d changes", edgeGateway.EdgeGateway.Name, len(submitted))
+	err = retryCallContext(ctx, func() *resource.RetryError {
+		task, err := edgeGateway.ConfigureServices(config)
+		if err != nil {
+			return retryOnTransientError(fmt.Errorf("Error reconfiguring edge gateway: %w", err))
+		}
+		return retryOnTransientError(task.WaitTaskCompletionContext(ctx))
+	})
+	c.invalidateEdgeGateway(edgeGateway.EdgeGateway.HREF)
+	return submitted, err
+}
@@ -0,0 +1,238 @@
+package vcd
+
+import (
+	"strings"
+	"sync"
+
+	govcd "github.com/vmware/go-vcloud-director/govcd"
+)
+
+// lookupCache keeps the orgs, VDCs, catalogs and edge gateways resources
+// look up by name, so that refreshing many resources in the same VDC
+// doesn't fetch the VDC once per resource. Resources that change the
+// contents of one of them must invalidate it.
+type lookupCache struct {
+	lock sync.Mutex
+	// Bumped by every invalidation, so that a lookup which started
+	// before the invalidation doesn't store what it found
+	generation uint64
+
+	orgs         map[string]govcd.Org         // By org name
+	vdcs         map[string]govcd.Vdc         // By org HREF and VDC name
+	catalogs     map[string]govcd.Catalog     // By org HREF and catalog name
+	edgeGateways map[string]govcd.EdgeGateway // By VDC HREF and edge gateway name
+}
+
+func newLookupCache() *lookupCache {
+	return &lookupCache{
+		orgs:         make(map[string]govcd.Org),
+		vdcs:         make(map[string]govcd.Vdc),
+		catalogs:     make(map[string]govcd.Catalog),
+		edgeGateways: make(map[string]govcd.EdgeGateway),
+	}
+}
+
+func lookupKey(parentHREF, name string) string {
+	return parentHREF + "\n" + name
+}
+
+// Returns the current generation, to pass to store once the lookup is done.
+func (c *lookupCache) start() uint64 {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.generation
+}
+
+// Runs store unless the cache has been invalidated since generation.
+func (c *lookupCache) store(generation uint64, store func()) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.generation == generation {
+		store()
+	}
+}
+
+func (c *lookupCache) invalidate(remove func()) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.generation++
+	remove()
+}
+
+// getOrg returns the org called name, like govcd.GetOrgByName, from the
+// lookup cache if it has it.
+func (c *VCDClient) getOrg(name string) (govcd.Org, error) {
+	cache := c.lookupCache
+	if cache == nil {
+		return govcd.GetOrgByName(c.VCDClient, name)
+	}
+
+	cache.lock.Lock()
+	org, ok := cache.orgs[name]
+	cache.lock.Unlock()
+	if ok {
+		return org, nil
+	}
+
+	generation := cache.start()
+	org, err := govcd.GetOrgByName(c.VCDClient, name)
+	if err == nil && org != (govcd.Org{}) {
+		cache.store(generation, func() { cache.orgs[name] = org })
+	}
+	return org, err
+}
+
+// getVdc returns the VDC of org called name, like org.GetVdcByName, from
+// the lookup cache if it has it.
+func (c *VCDClient) getVdc(org govcd.Org, name string) (govcd.Vdc, error) {
+	cache := c.lookupCache
+	if cache == nil {
+		return org.GetVdcByName(name)
+	}
+
+	key := lookupKey(org.Org.HREF, name)
+	cache.lock.Lock()
+	vdc, ok := cache.vdcs[key]
+	cache.lock.Unlock()
+	if ok {
+		return vdc, nil
+	}
+
+	generation := cache.start()
+	vdc, err := org.GetVdcByName(name)
+	if err == nil && vdc != (govcd.Vdc{}) {
+		cache.store(generation, func() { cache.vdcs[key] = vdc })
+	}
+	return vdc, err
+}
+
+// getCatalog returns the catalog of org called name, like org.FindCatalog,
+// from the lookup cache if it has it.
+func (c *VCDClient) getCatalog(org govcd.Org, name string) (govcd.Catalog, error) {
+	cache := c.lookupCache
+	if cache == nil {
+		return org.FindCatalog(name)
+	}
+
+	key := lookupKey(org.Org.HREF, name)
+	cache.lock.Lock()
+	catalog, ok := cache.catalogs[key]
+	cache.lock.Unlock()
+	if ok {
+		return catalog, nil
+	}
+
+	generation := cache.start()
+	catalog, err := org.FindCatalog(name)
+	if err == nil && catalog != (govcd.Catalog{}) {
+		cache.store(generation, func() { cache.catalogs[key] = catalog })
+	}
+	return catalog, err
+}
+
+// getEdgeGateway returns the edge gateway of vdc called name, like
+// vdc.FindEdgeGateway, from the lookup cache if it has it.
+func (c *VCDClient) getEdgeGateway(vdc govcd.Vdc, name string) (govcd.EdgeGateway, error) {
+	cache := c.lookupCache
+	if cache == nil {
+		return vdc.FindEdgeGateway(name)
+	}
+
+	key := lookupKey(vdc.Vdc.HREF, name)
+	cache.lock.Lock()
+	edgeGateway, ok := cache.edgeGateways[key]
+	cache.lock.Unlock()
+	if ok {
+		return edgeGateway, nil
+	}
+
+	generation := cache.start()
+	edgeGateway, err := vdc.FindEdgeGateway(name)
+	if err == nil && edgeGateway != (govcd.EdgeGateway{}) {
+		cache.store(generation, func() { cache.edgeGateways[key] = edgeGateway })
+	}
+	return edgeGateway, err
+}
+
+// invalidateOrg drops the org called name, and everything looked up in
+// it, from the lookup cache.
+func (c *VCDClient) invalidateOrg(name string) {
+	cache := c.lookupCache
+	if cache == nil {
+		return
+	}
+	cache.invalidate(func() {
+		org, ok := cache.orgs[name]
+		delete(cache.orgs, name)
+		if !ok {
+			return
+		}
+		for key, vdc := range cache.vdcs {
+			if strings.HasPrefix(key, lookupKey(org.Org.HREF, "")) {
+				delete(cache.vdcs, key)
+				removeEdgeGateways(cache, vdc.Vdc.HREF)
+			}
+		}
+		for key := range cache.catalogs {
+			if strings.HasPrefix(key, lookupKey(org.Org.HREF, "")) {
+				delete(cache.catalogs, key)
+			}
+		}
+	})
+}
+
+// invalidateVdc drops vdc from the lookup cache once vApps or networks
+// have been added to it or removed from it.
+func (c *VCDClient) invalidateVdc(vdc govcd.Vdc) {
+	cache := c.lookupCache
+	if cache == nil || vdc.Vdc == nil {
+		return
+	}
+	cache.invalidate(func() {
+		for key, cached := range cache.vdcs {
+			if cached.Vdc.HREF == vdc.Vdc.HREF {
+				delete(cache.vdcs, key)
+			}
+		}
+	})
+}
+
+// invalidateCatalog drops catalog from the lookup cache once items have
+// been added to it or removed from it, as it lists its items.
+func (c *VCDClient) invalidateCatalog(catalog govcd.Catalog) {
+	cache := c.lookupCache
+	if cache == nil || catalog.Catalog == nil {
+		return
+	}
+	cache.invalidate(func() {
+		for key, cached := range cache.catalogs {
+			if cached.Catalog.HREF == catalog.Catalog.HREF {
+				delete(cache.catalogs, key)
+			}
+		}
+	})
+}
+
+// invalidateEdgeGateway drops the edge gateway with href from the lookup
+// cache once its configuration has changed.
+func (c *VCDClient) invalidateEdgeGateway(href string) {
+	cache := c.lookupCache
+	if cache == nil {
+		return
+	}
+	cache.invalidate(func() {
+		for key, cached := range cache.edgeGateways {
+			if cached.EdgeGateway.HREF == href {
+				delete(cache.edgeGateways, key)
+			}
+		}
+	})
+}
+
+func removeEdgeGateways(cache *lookupCache, vdcHREF string) {
+	for key := range cache.edgeGateways {
+		if strings.HasPrefix(key, lookupKey(vdcHREF, "")) {
+			delete(cache.edgeGateways, key)
+		}
+	}
+}
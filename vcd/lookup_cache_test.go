@@ -0,0 +1,60 @@
+package vcd
+
+import (
+	"testing"
+
+	govcd "github.com/vmware/go-vcloud-director/govcd"
+	types "github.com/vmware/go-vcloud-director/types/v56"
+)
+
+func TestLookupCacheInvalidateOrg(t *testing.T) {
+	c := &VCDClient{lookupCache: newLookupCache()}
+	cache := c.lookupCache
+
+	cache.orgs["org1"] = govcd.Org{Org: &types.Org{HREF: "https://vcd/api/org/1"}}
+	cache.orgs["org2"] = govcd.Org{Org: &types.Org{HREF: "https://vcd/api/org/2"}}
+	cache.vdcs[lookupKey("https://vcd/api/org/1", "vdc1")] = govcd.Vdc{Vdc: &types.Vdc{HREF: "https://vcd/api/vdc/1"}}
+	cache.vdcs[lookupKey("https://vcd/api/org/2", "vdc1")] = govcd.Vdc{Vdc: &types.Vdc{HREF: "https://vcd/api/vdc/2"}}
+	cache.edgeGateways[lookupKey("https://vcd/api/vdc/1", "gw")] = govcd.EdgeGateway{EdgeGateway: &types.EdgeGateway{HREF: "https://vcd/api/gw/1"}}
+	cache.edgeGateways[lookupKey("https://vcd/api/vdc/2", "gw")] = govcd.EdgeGateway{EdgeGateway: &types.EdgeGateway{HREF: "https://vcd/api/gw/2"}}
+
+	c.invalidateOrg("org1")
+
+	if _, ok := cache.orgs["org1"]; ok {
+		t.Errorf("org1 is still cached")
+	}
+	if _, ok := cache.vdcs[lookupKey("https://vcd/api/org/1", "vdc1")]; ok {
+		t.Errorf("VDC of org1 is still cached")
+	}
+	if _, ok := cache.edgeGateways[lookupKey("https://vcd/api/vdc/1", "gw")]; ok {
+		t.Errorf("edge gateway of org1 is still cached")
+	}
+	if len(cache.orgs) != 1 || len(cache.vdcs) != 1 || len(cache.edgeGateways) != 1 {
+		t.Errorf("org2 was dropped too: %d orgs, %d VDCs, %d edge gateways",
+			len(cache.orgs), len(cache.vdcs), len(cache.edgeGateways))
+	}
+}
+
+func TestLookupCacheStoreAfterInvalidation(t *testing.T) {
+	c := &VCDClient{lookupCache: newLookupCache()}
+	cache := c.lookupCache
+
+	// A lookup that started before the edge gateway changed must not
+	// cache what it found
+	generation := cache.start()
+	c.invalidateEdgeGateway("https://vcd/api/gw/1")
+	cache.store(generation, func() {
+		cache.edgeGateways[lookupKey("https://vcd/api/vdc/1", "gw")] = govcd.EdgeGateway{}
+	})
+	if len(cache.edgeGateways) != 0 {
+		t.Errorf("stale edge gateway was cached")
+	}
+
+	generation = cache.start()
+	cache.store(generation, func() {
+		cache.edgeGateways[lookupKey("https://vcd/api/vdc/1", "gw")] = govcd.EdgeGateway{}
+	})
+	if len(cache.edgeGateways) != 1 {
+		t.Errorf("edge gateway wasn't cached")
+	}
+}
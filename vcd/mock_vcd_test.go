@@ -0,0 +1,1342 @@
+package vcd
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	govcd "github.com/vmware/go-vcloud-director/govcd"
+	types "github.com/vmware/go-vcloud-director/types/v56"
+)
+
+const (
+	mockVCDUser       = "mock-user"
+	mockVCDPassword   = "mock-password"
+	mockVCDOrg        = "mock-org"
+	mockVCDVdc        = "mock-vdc"
+	mockVCDEdge       = "mock-edge"
+	mockVCDCatalog    = "mock-catalog"
+	mockVCDExternalIP = "203.0.113.10"
+
+	// The vApp network the VMs of vApps added with addVApp connect to
+	mockVCDVAppNetwork = "mock-vapp-network"
+
+	mockVCDAuthHeader = "x-vcloud-authorization"
+)
+
+// mockVCD is an in-process fake of the parts of the vCD API the provider
+// and its tests use: login, the org, VDC and edge gateway of the tests,
+// networks, vApps and their VMs, a catalog of vApp templates, queries and
+// tasks. State is kept as the types/v56 structs and served as XML, so that
+// the govcd client runs unchanged against it. Tasks complete as soon as
+// they are created.
+type mockVCD struct {
+	server *httptest.Server
+
+	lock      sync.Mutex
+	nextID    int
+	requests  int
+	powerOffs int // Of vApps, to check they aren't power cycled
+	tokens    map[string]bool
+	tasks     map[string]*types.Task
+
+	org         *types.Org
+	vdc         *types.Vdc
+	catalog     *types.Catalog
+	edgeGateway *types.EdgeGateway
+	networks    map[string]*types.OrgVDCNetwork // By ID
+	vApps       map[string]*types.VApp          // By ID
+	metadata    map[string]map[string]string    // By href of the object
+
+	controlAccess map[string]*types.ControlAccessParams // By href of the vApp
+
+	catalogItems  map[string]*types.CatalogItem  // By ID
+	vAppTemplates map[string]*types.VAppTemplate // By ID
+}
+
+func newMockVCD() *mockVCD {
+	m := &mockVCD{
+		tokens:   make(map[string]bool),
+		tasks:    make(map[string]*types.Task),
+		networks: make(map[string]*types.OrgVDCNetwork),
+		vApps:    make(map[string]*types.VApp),
+		metadata: make(map[string]map[string]string),
+
+		controlAccess: make(map[string]*types.ControlAccessParams),
+
+		catalogItems:  make(map[string]*types.CatalogItem),
+		vAppTemplates: make(map[string]*types.VAppTemplate),
+	}
+	// TLS, as network hrefs are expected to be https
+	m.server = httptest.NewTLSServer(http.HandlerFunc(m.serveHTTP))
+
+	m.org = &types.Org{
+		HREF:     m.href("/org/1"),
+		Type:     "application/vnd.vmware.vcloud.org+xml",
+		ID:       "urn:vcloud:org:1",
+		Name:     mockVCDOrg,
+		FullName: mockVCDOrg,
+	}
+	m.vdc = &types.Vdc{
+		HREF:            m.href("/vdc/1"),
+		Type:            "application/vnd.vmware.vcloud.vdc+xml",
+		ID:              "urn:vcloud:vdc:1",
+		Name:            mockVCDVdc,
+		Status:          "1",
+		AllocationModel: "AllocationPool",
+		IsEnabled:       true,
+		Link: types.LinkList{
+			{
+				Rel:  "edgeGateways",
+				Type: "application/vnd.vmware.vcloud.query.records+xml",
+				HREF: m.href("/admin/vdc/1/edgeGateways"),
+			},
+			{
+				Rel:  "add",
+				Type: "application/vnd.vmware.vcloud.orgVdcNetwork+xml",
+				HREF: m.href("/admin/vdc/1/networks"),
+			},
+		},
+		AvailableNetworks: []*types.AvailableNetworks{{}},
+		ResourceEntities:  []*types.ResourceEntities{{}},
+	}
+	m.catalog = &types.Catalog{
+		HREF:         m.href("/catalog/1"),
+		Type:         "application/vnd.vmware.vcloud.catalog+xml",
+		ID:           "urn:vcloud:catalog:1",
+		Name:         mockVCDCatalog,
+		CatalogItems: []*types.CatalogItems{{}},
+	}
+	m.org.Link = types.LinkList{
+		{
+			Rel:  "down",
+			Type: m.vdc.Type,
+			Name: m.vdc.Name,
+			HREF: m.vdc.HREF,
+		},
+		{
+			Rel:  "down",
+			Type: m.catalog.Type,
+			Name: m.catalog.Name,
+			HREF: m.catalog.HREF,
+		},
+	}
+	m.edgeGateway = &types.EdgeGateway{
+		HREF:   m.href("/admin/edgeGateway/1"),
+		Type:   "application/vnd.vmware.admin.edgeGateway+xml",
+		ID:     "urn:vcloud:gateway:1",
+		Name:   mockVCDEdge,
+		Status: 1,
+		Configuration: &types.GatewayConfiguration{
+			GatewayBackingConfig: "compact",
+			GatewayInterfaces: &types.GatewayInterfaces{
+				GatewayInterface: []*types.GatewayInterface{
+					{
+						Name:          "mock-external",
+						DisplayName:   "mock-external",
+						InterfaceType: "uplink",
+						Network: &types.Reference{
+							HREF: m.href("/admin/network/external"),
+							Type: "application/vnd.vmware.admin.network+xml",
+							Name: "mock-external",
+						},
+						UseForDefaultRoute: true,
+					},
+				},
+			},
+			EdgeGatewayServiceConfiguration: &types.GatewayFeatures{
+				FirewallService: &types.FirewallService{
+					IsEnabled:     true,
+					DefaultAction: "drop",
+				},
+				NatService: &types.NatService{
+					IsEnabled: true,
+				},
+				GatewayIpsecVpnService: &types.GatewayIpsecVpnService{},
+			},
+		},
+	}
+	return m
+}
+
+func (m *mockVCD) close() {
+	m.server.Close()
+}
+
+// URL is the API endpoint of the mock, as set in the provider's url.
+func (m *mockVCD) URL() string {
+	return m.server.URL + "/api"
+}
+
+func (m *mockVCD) href(path string) string {
+	return m.server.URL + "/api" + path
+}
+
+// Returns a new ID, unique within the mock. Called with the lock held.
+func (m *mockVCD) newID() string {
+	m.nextID++
+	return strconv.Itoa(m.nextID)
+}
+
+// Records a finished task for operation on owner. Called with the lock
+// held.
+func (m *mockVCD) newTask(operation string, owner string) *types.Task {
+	id := m.newID()
+	task := &types.Task{
+		HREF:      m.href("/task/" + id),
+		Type:      "application/vnd.vmware.vcloud.task+xml",
+		ID:        "urn:vcloud:task:" + id,
+		Name:      "task",
+		Status:    "success",
+		Operation: operation,
+		Owner:     &types.Reference{HREF: owner},
+	}
+	m.tasks[id] = task
+	return task
+}
+
+// addVApp adds a vApp with VMs called vmNames to the VDC, for tests that
+// need vApps the mock can't build from catalog templates. The VMs have 2
+// CPUs, 1GB of memory, a 16GB disk and a NIC on mockVCDVAppNetwork.
+func (m *mockVCD) addVApp(name string, vmNames ...string) *types.VApp {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	vApp := m.newVApp(name)
+	vApp.Children = &types.VAppChildren{}
+	vApp.NetworkConfigSection = &types.NetworkConfigSection{
+		NetworkConfig: []types.VAppNetworkConfiguration{
+			{NetworkName: mockVCDVAppNetwork, Configuration: &types.NetworkConfiguration{FenceMode: "isolated"}},
+			{NetworkName: "none", Configuration: &types.NetworkConfiguration{FenceMode: "isolated"}},
+		},
+	}
+	for i, vmName := range vmNames {
+		vApp.Children.VM = append(vApp.Children.VM, m.newVM(vmName, i))
+	}
+	return vApp
+}
+
+// Returns the i-th VM of a vApp added with addVApp. Called with the lock
+// held.
+func (m *mockVCD) newVM(name string, i int) *types.VM {
+	id := m.newID()
+	return &types.VM{
+		HREF:   m.href("/vApp/vm-" + id),
+		Type:   "application/vnd.vmware.vcloud.vm+xml",
+		ID:     "urn:vcloud:vm:" + id,
+		Name:   name,
+		Status: 8,
+		VirtualHardwareSection: &types.VirtualHardwareSection{
+			Item: []*types.VirtualHardwareItem{
+				{ResourceType: hardwareItemCPU, InstanceID: 4, ElementName: "2 virtual CPU(s)", VirtualQuantity: 2, CoresPerSocket: 1},
+				{ResourceType: hardwareItemMemory, InstanceID: 5, ElementName: "1024 MB of memory", VirtualQuantity: 1024},
+				{
+					ResourceType:    hardwareItemDisk,
+					InstanceID:      2000,
+					ElementName:     "Hard disk 1",
+					AddressOnParent: 0,
+					HostResource:    []*types.VirtualHardwareHostResource{{BusType: 6, BusSubType: "lsilogic", Capacity: 16384}},
+				},
+			},
+		},
+		NetworkConnectionSection: &types.NetworkConnectionSection{
+			NetworkConnection: []*types.NetworkConnection{{
+				Network:                 mockVCDVAppNetwork,
+				IPAddress:               fmt.Sprintf("10.10.102.%d", 10+i),
+				MACAddress:              fmt.Sprintf("00:50:56:01:00:%02x", i),
+				IsConnected:             true,
+				IPAddressAllocationMode: "POOL",
+			}},
+		},
+		OperatingSystemSection: &types.OperatingSystemSection{
+			ID:          94,
+			OsType:      "ubuntu64Guest",
+			Description: "Ubuntu Linux (64-bit)",
+		},
+	}
+}
+
+// addVAppTemplate adds a vApp template with VMs called vmNames to the
+// catalog. Its VMs have the hardware of those of addVApp, a NIC without
+// an IP address on mockVCDVAppNetwork and a user configurable hostname
+// OVF property.
+func (m *mockVCD) addVAppTemplate(name string, vmNames ...string) *types.VAppTemplate {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	id := m.newID()
+	template := &types.VAppTemplate{
+		HREF:     m.href("/vAppTemplate/vappTemplate-" + id),
+		Type:     "application/vnd.vmware.vcloud.vAppTemplate+xml",
+		ID:       "urn:vcloud:vapptemplate:" + id,
+		Name:     name,
+		Status:   8,
+		Children: &types.VAppTemplateChildren{},
+		NetworkConfigSection: &types.NetworkConfigSection{
+			NetworkConfig: []types.VAppNetworkConfiguration{
+				{NetworkName: mockVCDVAppNetwork, Configuration: &types.NetworkConfiguration{FenceMode: "isolated"}},
+			},
+		},
+	}
+	for i, vmName := range vmNames {
+		vm := m.newVM(vmName, i)
+		vmID := strings.TrimPrefix(vm.ID, "urn:vcloud:vm:")
+		nic := vm.NetworkConnectionSection.NetworkConnection[0]
+		nic.IPAddress = ""
+		template.Children.VM = append(template.Children.VM, &types.VAppTemplate{
+			HREF:                     m.href("/vAppTemplate/vm-" + vmID),
+			Type:                     "application/vnd.vmware.vcloud.vm+xml",
+			ID:                       vm.ID,
+			Name:                     vmName,
+			Status:                   8,
+			VirtualHardwareSection:   vm.VirtualHardwareSection,
+			NetworkConnectionSection: vm.NetworkConnectionSection,
+			OperatingSystemSection:   vm.OperatingSystemSection,
+			ProductSection: &types.ProductSection{
+				Property: []*types.Property{{
+					Key:              "hostname",
+					Label:            "Hostname",
+					Type:             "string",
+					UserConfigurable: true,
+				}},
+			},
+		})
+	}
+	m.addCatalogItem(template)
+	return template
+}
+
+// Adds template to the catalog, as a catalog item of the same name.
+// Called with the lock held.
+func (m *mockVCD) addCatalogItem(template *types.VAppTemplate) {
+	m.vAppTemplates[strings.TrimPrefix(template.ID, "urn:vcloud:vapptemplate:")] = template
+
+	itemID := m.newID()
+	item := &types.CatalogItem{
+		HREF: m.href("/catalogItem/" + itemID),
+		Type: "application/vnd.vmware.vcloud.catalogItem+xml",
+		ID:   "urn:vcloud:catalogitem:" + itemID,
+		Name: template.Name,
+		Entity: &types.Entity{
+			HREF: template.HREF,
+			Type: template.Type,
+			Name: template.Name,
+		},
+	}
+	m.catalogItems[itemID] = item
+	m.catalog.CatalogItems[0].CatalogItem = append(m.catalog.CatalogItems[0].CatalogItem, &types.Reference{
+		HREF: item.HREF,
+		Type: item.Type,
+		Name: item.Name,
+	})
+}
+
+// Returns the VM of a vApp template at href. Called with the lock held.
+func (m *mockVCD) findTemplateVM(href string) *types.VAppTemplate {
+	for _, template := range m.vAppTemplates {
+		for _, vm := range template.Children.VM {
+			if vm.HREF == href {
+				return vm
+			}
+		}
+	}
+	return nil
+}
+
+// Returns a VM built from templateVM, named and connected as item says.
+// Called with the lock held.
+func (m *mockVCD) newVMFromTemplate(templateVM *types.VAppTemplate, item *types.SourcedCompositionItemParam) *types.VM {
+	vm := m.newVM(item.Source.Name, 0)
+	vm.VirtualHardwareSection = templateVM.VirtualHardwareSection
+	vm.OperatingSystemSection = templateVM.OperatingSystemSection
+	vm.ProductSection = templateVM.ProductSection
+	vm.NetworkConnectionSection = &types.NetworkConnectionSection{}
+	if params := item.InstantiationParams; params != nil && params.NetworkConnectionSection != nil {
+		vm.NetworkConnectionSection.PrimaryNetworkConnectionIndex = params.NetworkConnectionSection.PrimaryNetworkConnectionIndex
+		vm.NetworkConnectionSection.NetworkConnection = params.NetworkConnectionSection.NetworkConnection
+	}
+	return vm
+}
+
+// setMetadata sets a string metadata value on the object at href.
+func (m *mockVCD) setMetadata(href, key, value string) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if m.metadata[href] == nil {
+		m.metadata[href] = make(map[string]string)
+	}
+	m.metadata[href][key] = value
+}
+
+// Called with the lock held.
+func (m *mockVCD) newVApp(name string) *types.VApp {
+	id := m.newID()
+	vApp := &types.VApp{
+		HREF:   m.href("/vApp/vapp-" + id),
+		Type:   "application/vnd.vmware.vcloud.vApp+xml",
+		ID:     "urn:vcloud:vapp:" + id,
+		Name:   name,
+		Status: 8,
+		LeaseSettingsSection: &types.LeaseSettingsSection{
+			StorageLeaseInSeconds: 7776000,
+		},
+	}
+	m.vApps[id] = vApp
+	m.vdc.ResourceEntities[0].ResourceEntity = append(m.vdc.ResourceEntities[0].ResourceEntity, &types.ResourceReference{
+		HREF: vApp.HREF,
+		Type: vApp.Type,
+		Name: vApp.Name,
+	})
+	return vApp
+}
+
+// addNetwork adds an isolated network to the VDC.
+func (m *mockVCD) addNetwork(name string) *types.OrgVDCNetwork {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	network := &types.OrgVDCNetwork{
+		Name: name,
+		Configuration: &types.NetworkConfiguration{
+			FenceMode: "isolated",
+		},
+	}
+	m.addNetworkLocked(network)
+	return network
+}
+
+// Called with the lock held.
+func (m *mockVCD) addNetworkLocked(network *types.OrgVDCNetwork) {
+	id := m.newID()
+	network.HREF = m.href("/admin/network/" + id)
+	network.Type = "application/vnd.vmware.vcloud.orgVdcNetwork+xml"
+	network.ID = "urn:vcloud:network:" + id
+	network.Status = "1"
+	m.networks[id] = network
+	m.vdc.AvailableNetworks[0].Network = append(m.vdc.AvailableNetworks[0].Network, &types.Reference{
+		HREF: network.HREF,
+		Type: network.Type,
+		Name: network.Name,
+	})
+}
+
+// Called with the lock held.
+func (m *mockVCD) findVM(id string) *types.VM {
+	for _, vApp := range m.vApps {
+		if vApp.Children == nil {
+			continue
+		}
+		for _, vm := range vApp.Children.VM {
+			if vm.ID == "urn:vcloud:vm:"+id {
+				return vm
+			}
+		}
+	}
+	return nil
+}
+
+func (m *mockVCD) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.requests++
+	w.Header().Set("X-VMWARE-VCLOUD-REQUEST-ID", fmt.Sprintf("mock-request-%d", m.requests))
+
+	path := strings.TrimPrefix(r.URL.Path, "/api")
+	switch {
+	case path == "/versions" && r.Method == "GET":
+		m.writeVersions(w)
+		return
+	case path == "/sessions" && r.Method == "POST":
+		m.login(w, r)
+		return
+	}
+
+	token := r.Header.Get(mockVCDAuthHeader)
+	if !m.tokens[token] {
+		writeMockError(w, http.StatusUnauthorized, "this operation is denied")
+		return
+	}
+
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	switch {
+	case (path == "/session" || path == "/sessions") && r.Method == "GET":
+		w.WriteHeader(http.StatusOK)
+	case (path == "/session" || path == "/sessions") && r.Method == "DELETE":
+		delete(m.tokens, token)
+		w.WriteHeader(http.StatusNoContent)
+	case path == "/org" && r.Method == "GET":
+		writeMockXML(w, http.StatusOK, &types.OrgList{
+			Org: []*types.Org{{HREF: m.org.HREF, Type: m.org.Type, Name: m.org.Name}},
+		})
+	case m.href(path) == m.org.HREF && r.Method == "GET":
+		writeMockXML(w, http.StatusOK, m.org)
+	case m.href(path) == m.vdc.HREF && r.Method == "GET":
+		writeMockXML(w, http.StatusOK, m.vdc)
+	case m.href(path) == m.vdc.HREF+"/action/composeVApp" && r.Method == "POST":
+		m.composeVApp(w, r)
+	case m.href(path) == m.vdc.HREF+"/action/instantiateVAppTemplate" && r.Method == "POST":
+		m.instantiateVAppTemplate(w, r)
+	case path == "/admin/vdc/1/edgeGateways" && r.Method == "GET":
+		writeMockXML(w, http.StatusOK, &types.QueryResultEdgeGatewayRecordsType{
+			EdgeGatewayRecord: []*types.QueryResultEdgeGatewayRecordType{
+				{HREF: m.edgeGateway.HREF, Name: m.edgeGateway.Name, Vdc: m.vdc.HREF},
+			},
+		})
+	case m.href(path) == m.edgeGateway.HREF && r.Method == "GET":
+		writeMockXML(w, http.StatusOK, m.edgeGateway)
+	case m.href(path) == m.edgeGateway.HREF+"/action/configureServices" && r.Method == "POST":
+		m.configureServices(w, r)
+	case path == "/admin/vdc/1/networks" && r.Method == "POST":
+		m.createNetwork(w, r)
+	case len(parts) == 3 && parts[0] == "admin" && parts[1] == "network":
+		m.serveNetwork(w, r, parts[2])
+	case len(parts) == 3 && parts[0] == "vApp" && parts[2] == "metadata" && r.Method == "GET":
+		m.serveMetadata(w, m.href("/vApp/"+parts[1]))
+	case len(parts) >= 2 && parts[0] == "vApp" && strings.HasPrefix(parts[1], "vapp-"):
+		m.serveVApp(w, r, strings.TrimPrefix(parts[1], "vapp-"), parts[2:])
+	case len(parts) == 2 && parts[0] == "vApp" && strings.HasPrefix(parts[1], "vm-") && r.Method == "GET":
+		vm := m.findVM(strings.TrimPrefix(parts[1], "vm-"))
+		if vm == nil {
+			writeMockError(w, http.StatusForbidden, "no access to entity")
+			return
+		}
+		writeMockXML(w, http.StatusOK, vm)
+	case m.href(path) == m.catalog.HREF && r.Method == "GET":
+		writeMockXML(w, http.StatusOK, m.catalog)
+	case m.href(path) == m.catalog.HREF+"/action/captureVApp" && r.Method == "POST":
+		m.captureVApp(w, r)
+	case len(parts) == 2 && parts[0] == "catalogItem":
+		m.serveCatalogItem(w, r, parts[1])
+	case len(parts) == 2 && parts[0] == "vAppTemplate" && strings.HasPrefix(parts[1], "vappTemplate-") && r.Method == "GET":
+		template, ok := m.vAppTemplates[strings.TrimPrefix(parts[1], "vappTemplate-")]
+		if !ok {
+			writeMockError(w, http.StatusForbidden, "no access to entity")
+			return
+		}
+		writeMockXML(w, http.StatusOK, template)
+	case path == "/query" && r.Method == "GET":
+		m.query(w, r)
+	case len(parts) == 2 && parts[0] == "task" && r.Method == "GET":
+		task, ok := m.tasks[parts[1]]
+		if !ok {
+			writeMockError(w, http.StatusForbidden, "no access to entity")
+			return
+		}
+		writeMockXML(w, http.StatusOK, task)
+	default:
+		writeMockError(w, http.StatusNotFound, fmt.Sprintf("mock vCD doesn't implement %s %s", r.Method, r.URL.Path))
+	}
+}
+
+func (m *mockVCD) serveMetadata(w http.ResponseWriter, href string) {
+	_, isVApp := m.vApps[strings.TrimPrefix(href, m.href("/vApp/vapp-"))]
+	if !isVApp && m.findVM(strings.TrimPrefix(href, m.href("/vApp/vm-"))) == nil {
+		writeMockError(w, http.StatusForbidden, "no access to entity")
+		return
+	}
+	metadata := &types.Metadata{HREF: href + "/metadata"}
+	var keys []string
+	for key := range m.metadata[href] {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		metadata.MetadataEntry = append(metadata.MetadataEntry, &types.MetadataEntry{
+			Key:        key,
+			TypedValue: &types.TypedValue{XsiType: "MetadataStringValue", Value: m.metadata[href][key]},
+		})
+	}
+	writeMockXML(w, http.StatusOK, metadata)
+}
+
+func (m *mockVCD) writeVersions(w http.ResponseWriter) {
+	type versionInfo struct {
+		Version  string `xml:"Version"`
+		LoginUrl string `xml:"LoginUrl"`
+	}
+	versions := struct {
+		XMLName     xml.Name      `xml:"SupportedVersions"`
+		VersionInfo []versionInfo `xml:"VersionInfo"`
+	}{}
+	for _, version := range []string{"5.5", "27.0", "29.0"} {
+		versions.VersionInfo = append(versions.VersionInfo, versionInfo{
+			Version:  version,
+			LoginUrl: m.href("/sessions"),
+		})
+	}
+	writeMockXML(w, http.StatusOK, &versions)
+}
+
+func (m *mockVCD) login(w http.ResponseWriter, r *http.Request) {
+	user, password, ok := r.BasicAuth()
+	if !ok || user != mockVCDUser+"@"+mockVCDOrg || password != mockVCDPassword {
+		writeMockError(w, http.StatusUnauthorized, "invalid credentials")
+		return
+	}
+	token := "mock-token-" + m.newID()
+	m.tokens[token] = true
+	w.Header().Set(mockVCDAuthHeader, token)
+	w.WriteHeader(http.StatusOK)
+}
+
+func (m *mockVCD) configureServices(w http.ResponseWriter, r *http.Request) {
+	config := new(types.EdgeGatewayServiceConfiguration)
+	if err := xml.NewDecoder(r.Body).Decode(config); err != nil {
+		writeMockError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	services := m.edgeGateway.Configuration.EdgeGatewayServiceConfiguration
+	if config.FirewallService != nil {
+		services.FirewallService = config.FirewallService
+	}
+	if config.NatService != nil {
+		// Like vCD, SNAT rules don't keep ports or a protocol
+		for _, rule := range config.NatService.NatRule {
+			if rule.RuleType == "SNAT" && rule.GatewayNatRule != nil {
+				rule.GatewayNatRule.OriginalPort = ""
+				rule.GatewayNatRule.TranslatedPort = ""
+				rule.GatewayNatRule.Protocol = ""
+			}
+		}
+		services.NatService = config.NatService
+	}
+	if config.GatewayDhcpService != nil {
+		services.GatewayDhcpService = config.GatewayDhcpService
+	}
+	if config.GatewayIpsecVpnService != nil {
+		services.GatewayIpsecVpnService = config.GatewayIpsecVpnService
+	}
+	writeMockXML(w, http.StatusAccepted, m.newTask("configureServices", m.edgeGateway.HREF))
+}
+
+func (m *mockVCD) createNetwork(w http.ResponseWriter, r *http.Request) {
+	network := new(types.OrgVDCNetwork)
+	if err := xml.NewDecoder(r.Body).Decode(network); err != nil {
+		writeMockError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	for _, existing := range m.networks {
+		if existing.Name == network.Name {
+			writeMockError(w, http.StatusBadRequest, fmt.Sprintf("network %s already exists", network.Name))
+			return
+		}
+	}
+
+	m.addNetworkLocked(network)
+
+	created := *network
+	created.Tasks = &types.TasksInProgress{
+		Task: []*types.Task{m.newTask("createNetwork", network.HREF)},
+	}
+	writeMockXML(w, http.StatusCreated, &created)
+}
+
+func (m *mockVCD) serveNetwork(w http.ResponseWriter, r *http.Request, id string) {
+	network, ok := m.networks[id]
+	if !ok {
+		writeMockError(w, http.StatusForbidden, "no access to entity")
+		return
+	}
+	switch r.Method {
+	case "GET":
+		writeMockXML(w, http.StatusOK, network)
+	case "DELETE":
+		delete(m.networks, id)
+		refs := m.vdc.AvailableNetworks[0].Network[:0]
+		for _, ref := range m.vdc.AvailableNetworks[0].Network {
+			if ref.HREF != network.HREF {
+				refs = append(refs, ref)
+			}
+		}
+		m.vdc.AvailableNetworks[0].Network = refs
+		writeMockXML(w, http.StatusAccepted, m.newTask("deleteNetwork", network.HREF))
+	default:
+		writeMockError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+func (m *mockVCD) composeVApp(w http.ResponseWriter, r *http.Request) {
+	params := new(types.ComposeVAppParams)
+	if err := xml.NewDecoder(r.Body).Decode(params); err != nil {
+		writeMockError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	for _, vApp := range m.vApps {
+		if vApp.Name == params.Name {
+			writeMockError(w, http.StatusBadRequest, fmt.Sprintf("vApp %s already exists", params.Name))
+			return
+		}
+	}
+	var templateVM *types.VAppTemplate
+	if params.SourcedItem != nil {
+		templateVM = m.findTemplateVM(params.SourcedItem.Source.HREF)
+		if templateVM == nil {
+			writeMockError(w, http.StatusBadRequest, "mock vCD only composes vApps from VMs of vApp templates")
+			return
+		}
+	}
+	vApp := m.newVApp(params.Name)
+	vApp.Description = params.Description
+	task := m.newTask("composeVApp", vApp.HREF)
+	if templateVM == nil {
+		writeMockXML(w, http.StatusCreated, task)
+		return
+	}
+	vApp.Children = &types.VAppChildren{
+		VM: []*types.VM{m.newVMFromTemplate(templateVM, params.SourcedItem)},
+	}
+	// vApps composed from templates are returned with their task
+	response := *vApp
+	response.Tasks = &types.TasksInProgress{Task: []*types.Task{task}}
+	writeMockXML(w, http.StatusCreated, &response)
+}
+
+// Captures a vApp as a vApp template of the catalog, whose VMs are copies
+// of those of the vApp.
+func (m *mockVCD) captureVApp(w http.ResponseWriter, r *http.Request) {
+	params := new(types.CaptureVAppParams)
+	if err := xml.NewDecoder(r.Body).Decode(params); err != nil {
+		writeMockError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	var vApp *types.VApp
+	for _, v := range m.vApps {
+		if params.Source != nil && v.HREF == params.Source.HREF {
+			vApp = v
+		}
+	}
+	if vApp == nil {
+		writeMockError(w, http.StatusBadRequest, "no vApp to capture")
+		return
+	}
+	for _, item := range m.catalogItems {
+		if item.Name == params.Name {
+			writeMockError(w, http.StatusBadRequest, fmt.Sprintf("catalog item %s already exists", params.Name))
+			return
+		}
+	}
+
+	id := m.newID()
+	template := &types.VAppTemplate{
+		HREF:                 m.href("/vAppTemplate/vappTemplate-" + id),
+		Type:                 "application/vnd.vmware.vcloud.vAppTemplate+xml",
+		ID:                   "urn:vcloud:vapptemplate:" + id,
+		Name:                 params.Name,
+		Description:          params.Description,
+		Status:               8,
+		Children:             &types.VAppTemplateChildren{},
+		NetworkConfigSection: vApp.NetworkConfigSection,
+		CustomizationSection: params.CustomizationSection,
+	}
+	if vApp.Children != nil {
+		for _, vm := range vApp.Children.VM {
+			template.Children.VM = append(template.Children.VM, &types.VAppTemplate{
+				HREF:                     m.href("/vAppTemplate/vm-" + m.newID()),
+				Type:                     vm.Type,
+				Name:                     vm.Name,
+				Status:                   8,
+				VirtualHardwareSection:   vm.VirtualHardwareSection,
+				NetworkConnectionSection: vm.NetworkConnectionSection,
+				OperatingSystemSection:   vm.OperatingSystemSection,
+				ProductSection:           vm.ProductSection,
+			})
+		}
+	}
+	m.addCatalogItem(template)
+
+	response := *template
+	response.Tasks = &types.TasksInProgress{Task: []*types.Task{m.newTask("vdcCaptureTemplate", template.HREF)}}
+	writeMockXML(w, http.StatusCreated, &response)
+}
+
+func (m *mockVCD) serveCatalogItem(w http.ResponseWriter, r *http.Request, id string) {
+	item, ok := m.catalogItems[id]
+	if !ok {
+		writeMockError(w, http.StatusForbidden, "no access to entity")
+		return
+	}
+	switch r.Method {
+	case "GET":
+		writeMockXML(w, http.StatusOK, item)
+	case "DELETE":
+		delete(m.catalogItems, id)
+		delete(m.vAppTemplates, strings.TrimPrefix(item.Entity.HREF, m.href("/vAppTemplate/vappTemplate-")))
+		refs := m.catalog.CatalogItems[0].CatalogItem[:0]
+		for _, ref := range m.catalog.CatalogItems[0].CatalogItem {
+			if ref.HREF != item.HREF {
+				refs = append(refs, ref)
+			}
+		}
+		m.catalog.CatalogItems[0].CatalogItem = refs
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeMockError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+// mockInstantiationHardware is the CPU and memory a vApp template
+// instantiation sets on the VMs of its SourcedItems, which the types only
+// marshal with the prefixes of their OVF namespaces.
+type mockInstantiationHardware struct {
+	SourcedItem []struct {
+		InstantiationParams struct {
+			VirtualHardwareSection struct {
+				Item []*types.VirtualHardwareItem `xml:"Item"`
+			}
+		}
+	}
+}
+
+// Instantiates all the VMs of a vApp template, with the names, NICs and
+// hardware of the SourcedItems that reference them.
+func (m *mockVCD) instantiateVAppTemplate(w http.ResponseWriter, r *http.Request) {
+	body, err := ioutil.ReadAll(r.Body)
+	params := new(types.InstantiateVAppTemplateParams)
+	hardware := new(mockInstantiationHardware)
+	if err == nil {
+		err = xml.Unmarshal(body, params)
+	}
+	if err == nil {
+		err = xml.Unmarshal(body, hardware)
+	}
+	if err != nil {
+		writeMockError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	var template *types.VAppTemplate
+	for _, t := range m.vAppTemplates {
+		if params.Source != nil && t.HREF == params.Source.HREF {
+			template = t
+		}
+	}
+	if template == nil {
+		writeMockError(w, http.StatusBadRequest, "no vApp template to instantiate")
+		return
+	}
+	for _, vApp := range m.vApps {
+		if vApp.Name == params.Name {
+			writeMockError(w, http.StatusBadRequest, fmt.Sprintf("vApp %s already exists", params.Name))
+			return
+		}
+	}
+
+	vApp := m.newVApp(params.Name)
+	vApp.Description = params.Description
+	vApp.NetworkConfigSection = template.NetworkConfigSection
+	if params.InstantiationParams != nil && params.InstantiationParams.NetworkConfigSection != nil {
+		vApp.NetworkConfigSection = params.InstantiationParams.NetworkConfigSection
+	}
+	vApp.Children = &types.VAppChildren{}
+	for _, templateVM := range template.Children.VM {
+		item := &types.SourcedCompositionItemParam{
+			Source: &types.Reference{HREF: templateVM.HREF, Name: templateVM.Name},
+		}
+		var items []*types.VirtualHardwareItem
+		for i, sourced := range params.SourcedItem {
+			if sourced.Source != nil && sourced.Source.HREF == templateVM.HREF {
+				item = sourced
+				if i < len(hardware.SourcedItem) {
+					items = hardware.SourcedItem[i].InstantiationParams.VirtualHardwareSection.Item
+				}
+			}
+		}
+		vm := m.newVMFromTemplate(templateVM, item)
+		if item.VMGeneralParams != nil && item.VMGeneralParams.Name != "" {
+			vm.Name = item.VMGeneralParams.Name
+		}
+		if item.InstantiationParams == nil || item.InstantiationParams.NetworkConnectionSection == nil {
+			vm.NetworkConnectionSection = templateVM.NetworkConnectionSection
+		}
+		if len(items) > 0 {
+			section := &types.VirtualHardwareSection{}
+			for _, templateItem := range templateVM.VirtualHardwareSection.Item {
+				hardwareItem := *templateItem
+				for _, changed := range items {
+					if changed.ResourceType == hardwareItem.ResourceType {
+						hardwareItem.ElementName = changed.ElementName
+						hardwareItem.VirtualQuantity = changed.VirtualQuantity
+					}
+				}
+				section.Item = append(section.Item, &hardwareItem)
+			}
+			vm.VirtualHardwareSection = section
+		}
+		vApp.Children.VM = append(vApp.Children.VM, vm)
+	}
+
+	response := *vApp
+	response.Tasks = &types.TasksInProgress{Task: []*types.Task{m.newTask("vdcInstantiateVapp", vApp.HREF)}}
+	writeMockXML(w, http.StatusCreated, &response)
+}
+
+func (m *mockVCD) serveVApp(w http.ResponseWriter, r *http.Request, id string, action []string) {
+	vApp, ok := m.vApps[id]
+	if !ok {
+		writeMockError(w, http.StatusForbidden, "no access to entity")
+		return
+	}
+	switch strings.Join(action, "/") {
+	case "":
+		switch r.Method {
+		case "GET":
+			writeMockXML(w, http.StatusOK, vApp)
+		case "DELETE":
+			if vApp.Deployed {
+				writeMockError(w, http.StatusBadRequest, "vApp must be undeployed before it is deleted")
+				return
+			}
+			delete(m.vApps, id)
+			refs := m.vdc.ResourceEntities[0].ResourceEntity[:0]
+			for _, ref := range m.vdc.ResourceEntities[0].ResourceEntity {
+				if ref.HREF != vApp.HREF {
+					refs = append(refs, ref)
+				}
+			}
+			m.vdc.ResourceEntities[0].ResourceEntity = refs
+			writeMockXML(w, http.StatusAccepted, m.newTask("vdcDeleteVapp", vApp.HREF))
+		default:
+			writeMockError(w, http.StatusMethodNotAllowed, "method not allowed")
+		}
+	case "action/recomposeVApp":
+		params := new(types.ReComposeVAppParams)
+		if err := xml.NewDecoder(r.Body).Decode(params); err != nil {
+			writeMockError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		if params.SourcedItem == nil {
+			writeMockError(w, http.StatusBadRequest, "mock vCD only recomposes vApps to add VMs")
+			return
+		}
+		templateVM := m.findTemplateVM(params.SourcedItem.Source.HREF)
+		if templateVM == nil {
+			writeMockError(w, http.StatusBadRequest, "mock vCD only adds VMs of vApp templates")
+			return
+		}
+		if vApp.Children == nil {
+			vApp.Children = &types.VAppChildren{}
+		}
+		vApp.Children.VM = append(vApp.Children.VM, m.newVMFromTemplate(templateVM, params.SourcedItem))
+		writeMockXML(w, http.StatusAccepted, m.newTask("vappRecompose", vApp.HREF))
+	case "controlAccess":
+		params, ok := m.controlAccess[vApp.HREF]
+		if !ok {
+			params = &types.ControlAccessParams{}
+		}
+		writeMockXML(w, http.StatusOK, params)
+	case "action/controlAccess":
+		params := new(types.ControlAccessParams)
+		if err := xml.NewDecoder(r.Body).Decode(params); err != nil {
+			writeMockError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		m.controlAccess[vApp.HREF] = params
+		writeMockXML(w, http.StatusOK, params)
+	case "action/undeploy":
+		m.setVAppPower(vApp, false)
+		writeMockXML(w, http.StatusAccepted, m.newTask("vappUndeployPowerOff", vApp.HREF))
+	case "power/action/powerOff":
+		m.powerOffs++
+		m.setVAppPower(vApp, false)
+		writeMockXML(w, http.StatusAccepted, m.newTask("vappPowerOff", vApp.HREF))
+	case "action/deploy", "power/action/powerOn":
+		m.setVAppPower(vApp, true)
+		writeMockXML(w, http.StatusAccepted, m.newTask("vappDeploy", vApp.HREF))
+	default:
+		writeMockError(w, http.StatusNotFound, fmt.Sprintf("mock vCD doesn't implement %s %s", r.Method, r.URL.Path))
+	}
+}
+
+func (m *mockVCD) setVAppPower(vApp *types.VApp, on bool) {
+	status := 8 // POWERED_OFF
+	if on {
+		status = 4 // POWERED_ON
+	}
+	vApp.Deployed = on
+	vApp.Status = status
+	if vApp.Children != nil {
+		for _, vm := range vApp.Children.VM {
+			vm.Deployed = on
+			vm.Status = status
+		}
+	}
+}
+
+// mockRecord is a record the query API can return: its attributes to
+// filter and sort on, and how to add it to the results.
+type mockRecord struct {
+	attributes map[string]string
+	add        func(results *types.QueryResultRecordsType)
+}
+
+// Answers the query API for vApps, VMs and edge gateways, a page at a
+// time. Filters are limited to attribute==value conditions joined with
+// ';', where value may end with a '*' wildcard.
+func (m *mockVCD) query(w http.ResponseWriter, r *http.Request) {
+	params := r.URL.Query()
+
+	var records []mockRecord
+	switch params.Get("type") {
+	case "vApp":
+		for _, vApp := range m.sortedVApps() {
+			record := &types.QueryResultVAppRecordType{
+				HREF:     vApp.HREF,
+				Name:     vApp.Name,
+				Deployed: vApp.Deployed,
+				Status:   types.VAppStatuses[vApp.Status],
+				VdcHREF:  m.vdc.HREF,
+				VdcName:  m.vdc.Name,
+			}
+			if vApp.Children != nil {
+				record.NumberOfVMs = len(vApp.Children.VM)
+			}
+			records = append(records, mockRecord{
+				attributes: map[string]string{"name": record.Name, "status": record.Status, "vdc": record.VdcHREF},
+				add: func(results *types.QueryResultRecordsType) {
+					results.VAppRecord = append(results.VAppRecord, record)
+				},
+			})
+		}
+	case "vm":
+		for _, vApp := range m.sortedVApps() {
+			if vApp.Children == nil {
+				continue
+			}
+			for _, vm := range vApp.Children.VM {
+				record := &types.QueryResultVMRecordType{
+					HREF:           vm.HREF,
+					Name:           vm.Name,
+					Deployed:       vm.Deployed,
+					Status:         types.VAppStatuses[vm.Status],
+					VdcHREF:        m.vdc.HREF,
+					VAppParentHREF: vApp.HREF,
+					VAppParentName: vApp.Name,
+				}
+				records = append(records, mockRecord{
+					attributes: map[string]string{
+						"name":           record.Name,
+						"status":         record.Status,
+						"vdc":            record.VdcHREF,
+						"isVAppTemplate": "false",
+						"containerName":  record.VAppParentName,
+					},
+					add: func(results *types.QueryResultRecordsType) {
+						results.VMRecord = append(results.VMRecord, record)
+					},
+				})
+			}
+		}
+	case "edgeGateway":
+		record := &types.QueryResultEdgeGatewayRecordType{
+			HREF: m.edgeGateway.HREF,
+			Name: m.edgeGateway.Name,
+			Vdc:  m.vdc.HREF,
+		}
+		records = append(records, mockRecord{
+			attributes: map[string]string{"name": record.Name, "vdc": record.Vdc},
+			add: func(results *types.QueryResultRecordsType) {
+				results.EdgeGatewayRecord = append(results.EdgeGatewayRecord, record)
+			},
+		})
+	default:
+		writeMockError(w, http.StatusBadRequest, fmt.Sprintf("mock vCD doesn't implement query type %q", params.Get("type")))
+		return
+	}
+
+	match, err := mockQueryFilter(params.Get("filter"))
+	if err != nil {
+		writeMockError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	matching := records[:0]
+	for _, record := range records {
+		if match(record.attributes) {
+			matching = append(matching, record)
+		}
+	}
+	if key := params.Get("sortAsc"); key != "" {
+		sort.SliceStable(matching, func(i, j int) bool {
+			return matching[i].attributes[key] < matching[j].attributes[key]
+		})
+	}
+	if key := params.Get("sortDesc"); key != "" {
+		sort.SliceStable(matching, func(i, j int) bool {
+			return matching[i].attributes[key] > matching[j].attributes[key]
+		})
+	}
+
+	pageSize, _ := strconv.Atoi(params.Get("pageSize"))
+	if pageSize <= 0 {
+		pageSize = 25
+	}
+	page, _ := strconv.Atoi(params.Get("page"))
+	if page <= 0 {
+		page = 1
+	}
+	results := &types.QueryResultRecordsType{
+		HREF:     m.href("/query?" + r.URL.RawQuery),
+		Page:     page,
+		PageSize: pageSize,
+		Total:    float64(len(matching)),
+	}
+	for i := (page - 1) * pageSize; i < len(matching) && i < page*pageSize; i++ {
+		matching[i].add(results)
+	}
+	if page*pageSize < len(matching) {
+		params.Set("page", strconv.Itoa(page+1))
+		results.Link = append(results.Link, &types.Link{
+			Rel:  "nextPage",
+			Type: "application/vnd.vmware.vcloud.query.records+xml",
+			HREF: m.href("/query?" + params.Encode()),
+		})
+	}
+	writeMockXML(w, http.StatusOK, results)
+}
+
+// Returns the vApps in the order they were created. Called with the lock
+// held.
+func (m *mockVCD) sortedVApps() []*types.VApp {
+	var vApps []*types.VApp
+	for _, vApp := range m.vApps {
+		vApps = append(vApps, vApp)
+	}
+	sort.Slice(vApps, func(i, j int) bool {
+		a, _ := strconv.Atoi(strings.TrimPrefix(vApps[i].ID, "urn:vcloud:vapp:"))
+		b, _ := strconv.Atoi(strings.TrimPrefix(vApps[j].ID, "urn:vcloud:vapp:"))
+		return a < b
+	})
+	return vApps
+}
+
+func mockQueryFilter(filter string) (func(attributes map[string]string) bool, error) {
+	conditions := make(map[string]string)
+	for _, condition := range strings.Split(filter, ";") {
+		// The mock only implements ANDs, so the parentheses grouping
+		// conditions make no difference
+		condition = strings.Trim(condition, "()")
+		if condition == "" {
+			continue
+		}
+		parts := strings.SplitN(condition, "==", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("mock vCD doesn't implement filter %q", condition)
+		}
+		conditions[parts[0]] = parts[1]
+	}
+	for key := range conditions {
+		switch key {
+		case "name", "status", "vdc", "isVAppTemplate", "containerName":
+		default:
+			return nil, fmt.Errorf("mock vCD doesn't filter on %q", key)
+		}
+	}
+	return func(attributes map[string]string) bool {
+		for key, pattern := range conditions {
+			value, ok := attributes[key]
+			if !ok {
+				return false
+			}
+			if prefix := strings.TrimSuffix(pattern, "*"); prefix != pattern {
+				if !strings.HasPrefix(value, prefix) {
+					return false
+				}
+			} else if value != pattern {
+				return false
+			}
+		}
+		return true
+	}, nil
+}
+
+func writeMockXML(w http.ResponseWriter, status int, v interface{}) {
+	body, err := xml.Marshal(v)
+	if err != nil {
+		writeMockError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(status)
+	w.Write([]byte(xml.Header))
+	w.Write(body)
+}
+
+func writeMockError(w http.ResponseWriter, status int, message string) {
+	minorErrorCode := strings.ToUpper(strings.Replace(http.StatusText(status), " ", "_", -1))
+	if status == http.StatusForbidden {
+		// What vCD answers for entities that don't exist
+		minorErrorCode = "ACCESS_TO_RESOURCE_IS_FORBIDDEN"
+	}
+	body, _ := xml.Marshal(&types.Error{
+		Message:        message,
+		MajorErrorCode: status,
+		MinorErrorCode: minorErrorCode,
+	})
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(status)
+	w.Write([]byte(xml.Header))
+	w.Write(body)
+}
+
+// Returns a client logged in to the mock vCD and its VDC.
+func testMockVCDClient(t *testing.T) (*VCDClient, govcd.Vdc) {
+	config := Config{
+		User:         mockVCDUser,
+		Password:     mockVCDPassword,
+		Org:          mockVCDOrg,
+		Href:         testMockVCD.URL(),
+		InsecureFlag: true,
+	}
+	client, err := config.Client()
+	if err != nil {
+		t.Fatalf("error logging in: %s", err)
+	}
+	_, vdc, err := client.getOrgAndVdc(schema.TestResourceDataRaw(t, map[string]*schema.Schema{
+		"org": {Type: schema.TypeString, Optional: true},
+		"vdc": {Type: schema.TypeString, Optional: true},
+	}, map[string]interface{}{"org": mockVCDOrg, "vdc": mockVCDVdc}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client, vdc
+}
+
+// Checks the vApp, VM and query endpoints of the mock, which no resource
+// test reaches as vcd_vapp only builds vApps from catalog templates.
+func TestMockVCDVApps(t *testing.T) {
+	if testMockVCD == nil {
+		t.Skip("Only runs against the mock vCD")
+	}
+	testMockVCD.addVApp("mock-seeded", "mock-vm1", "mock-vm2")
+	client, vdc := testMockVCDClient(t)
+
+	if err := vdc.ComposeRawVApp("mock-raw"); err != nil {
+		t.Fatalf("error composing vApp: %s", err)
+	}
+	vApp, err := vdc.FindVAppByName("mock-raw")
+	if err != nil {
+		t.Fatalf("error finding composed vApp: %s", err)
+	}
+
+	seeded, err := vdc.FindVAppByName("mock-seeded")
+	if err != nil {
+		t.Fatalf("error finding seeded vApp: %s", err)
+	}
+	vm, err := vdc.FindVMByName(seeded, "mock-vm2")
+	if err != nil {
+		t.Fatalf("error finding VM: %s", err)
+	}
+	if vm.VM.Name != "mock-vm2" {
+		t.Errorf("got VM %q, want mock-vm2", vm.VM.Name)
+	}
+
+	results, err := client.Query(map[string]string{"type": "vm", "filter": "name==mock-vm*"})
+	if err != nil {
+		t.Fatalf("error querying VMs: %s", err)
+	}
+	if len(results.Results.VMRecord) != 2 {
+		t.Errorf("got %d VM records, want 2", len(results.Results.VMRecord))
+	}
+
+	task, err := vApp.Undeploy()
+	if err == nil {
+		err = task.WaitTaskCompletion()
+	}
+	if err != nil {
+		t.Fatalf("error undeploying vApp: %s", err)
+	}
+	task, err = vApp.Delete()
+	if err == nil {
+		err = task.WaitTaskCompletion()
+	}
+	if err != nil {
+		t.Fatalf("error deleting vApp: %s", err)
+	}
+	if _, err := vdc.FindVAppByName("mock-raw"); err == nil {
+		t.Errorf("vApp still exists after deletion")
+	}
+}
+
+// TestMockVCDTemplateVMs checks that vApps are composed from, and VMs
+// added from, the VM of a multi-VM template they name.
+func TestMockVCDTemplateVMs(t *testing.T) {
+	if testMockVCD == nil {
+		t.Skip("Only runs against the mock vCD")
+	}
+	template := testMockVCD.addVAppTemplate("mock-three-tier", "web", "app", "db")
+	testMockVCD.lock.Lock()
+	for i, vm := range template.Children.VM {
+		vm.OperatingSystemSection = &types.OperatingSystemSection{OsType: vm.Name + "-os"}
+		vm.NetworkConnectionSection.PrimaryNetworkConnectionIndex = i
+	}
+	testMockVCD.lock.Unlock()
+	network := testMockVCD.addNetwork("mock-template-vms")
+
+	client, vdc := testMockVCDClient(t)
+	org, err := client.getOrg(mockVCDOrg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	catalog, err := client.getCatalog(org, mockVCDCatalog)
+	if err != nil {
+		t.Fatal(err)
+	}
+	item, err := catalog.FindCatalogItem("mock-three-tier")
+	if err != nil {
+		t.Fatal(err)
+	}
+	vappTemplate, err := item.GetVAppTemplate()
+	if err != nil {
+		t.Fatal(err)
+	}
+	nets := []*types.OrgVDCNetwork{network}
+
+	if _, err := vappTemplate.FindVM("cache"); err == nil {
+		t.Errorf("expected an error finding a VM the template doesn't have")
+	}
+
+	task, err := vdc.ComposeVApp(nets, vappTemplate, "db", types.Reference{}, "mock-three-tier", "", true)
+	if err == nil {
+		err = task.WaitTaskCompletion()
+	}
+	if err != nil {
+		t.Fatalf("error composing vApp: %s", err)
+	}
+	vApp, err := vdc.FindVAppByName("mock-three-tier")
+	if err != nil {
+		t.Fatal(err)
+	}
+	task, err = vApp.AddVM(nets, vappTemplate, "app", "mock-app", true)
+	if err == nil {
+		err = task.WaitTaskCompletion()
+	}
+	if err != nil {
+		t.Fatalf("error adding VM: %s", err)
+	}
+
+	want := map[string]int{"db": 2, "mock-app": 1}
+	for name, index := range want {
+		vm, err := vdc.FindVMByName(vApp, name)
+		if err != nil {
+			t.Fatalf("error finding VM %s: %s", name, err)
+		}
+		source := template.Children.VM[index]
+		if got := vm.VM.OperatingSystemSection.OsType; got != source.Name+"-os" {
+			t.Errorf("VM %s was built from the template VM with OS %s, want %s", name, got, source.Name+"-os")
+		}
+		if got := vm.VM.NetworkConnectionSection.PrimaryNetworkConnectionIndex; got != index {
+			t.Errorf("VM %s has primary NIC %d, want %d from the template VM", name, got, index)
+		}
+		if nics := vm.VM.NetworkConnectionSection.NetworkConnection; len(nics) != 1 || nics[0].Network != network.Name {
+			t.Errorf("VM %s isn't connected to %s", name, network.Name)
+		}
+	}
+}
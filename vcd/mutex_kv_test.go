@@ -0,0 +1,37 @@
+package vcd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMutexKV(t *testing.T) {
+	m := newMutexKV()
+
+	m.Lock("gw1")
+	done := make(chan struct{})
+	go func() {
+		m.Lock("gw2")
+		m.Unlock("gw2")
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatalf("locking gw2 waited for gw1")
+	}
+
+	locked := make(chan struct{})
+	go func() {
+		m.Lock("gw1")
+		close(locked)
+		m.Unlock("gw1")
+	}()
+	select {
+	case <-locked:
+		t.Fatalf("gw1 was locked twice")
+	case <-time.After(50 * time.Millisecond):
+	}
+	m.Unlock("gw1")
+	<-locked
+}
@@ -1,6 +1,10 @@
 package vcd
 
 import (
+	"fmt"
+	"regexp"
+	"strings"
+
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/terraform"
 )
@@ -11,23 +15,54 @@ func Provider() terraform.ResourceProvider {
 		Schema: map[string]*schema.Schema{
 			"user": &schema.Schema{
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc("VCD_USER", nil),
 				Description: "The user name for vcd API operations.",
 			},
 
 			"password": &schema.Schema{
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
+				Sensitive:   true,
 				DefaultFunc: schema.EnvDefaultFunc("VCD_PASSWORD", nil),
 				Description: "The user password for vcd API operations.",
 			},
 
+			"auth_type": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("VCD_AUTH_TYPE", "password"),
+				Description:  "How to log in: password, token or saml_adfs (defaults to password)",
+				ValidateFunc: validateAuthType,
+			},
+
+			"token": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				DefaultFunc: schema.EnvDefaultFunc("VCD_TOKEN", nil),
+				Description: "A session token to use instead of logging in, when auth_type is token.",
+			},
+
 			"org": &schema.Schema{
 				Type:        schema.TypeString,
 				Required:    true,
 				DefaultFunc: schema.EnvDefaultFunc("VCD_ORG", nil),
-				Description: "The vcd org for API operations",
+				Description: "The vcd org for API operations, and the default org of resources which don't set one",
+			},
+
+			"vdc": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("VCD_VDC", nil),
+				Description: "The default vdc of resources which don't set one",
+			},
+
+			"sysorg": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("VCD_SYS_ORG", nil),
+				Description: "The vcd org to log in to, if different from org. Use System to manage tenant orgs as a system administrator.",
 			},
 
 			"url": &schema.Schema{
@@ -47,7 +82,7 @@ func Provider() terraform.ResourceProvider {
 				Type:        schema.TypeInt,
 				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc("VCD_MAX_RETRY_TIMEOUT", 60),
-				Description: "Max num seconds to wait for successful response when operating on resources within vCloud (defaults to 60)",
+				Description: "Max num seconds to wait for a vApp IP address when reading it (defaults to 60). Other operations use the resource timeouts.",
 			},
 
 			"allow_unverified_ssl": &schema.Schema{
@@ -56,17 +91,86 @@ func Provider() terraform.ResourceProvider {
 				DefaultFunc: schema.EnvDefaultFunc("VCD_ALLOW_UNVERIFIED_SSL", false),
 				Description: "If set, VCDClient will permit unverifiable SSL certificates.",
 			},
+
+			"api_version": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("VCD_API_VERSION", nil),
+				Description: "The vCD API version to use, instead of the highest version supported by both the provider and the server.",
+			},
+
+			"cache_token": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("VCD_CACHE_TOKEN", false),
+				Description: "If set, the session token is kept on disk and reused by later runs until it expires.",
+			},
+
+			"cache_lookups": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("VCD_CACHE_LOOKUPS", true),
+				Description: "If set, orgs, VDCs, catalogs and edge gateways are looked up once per run instead of once per resource.",
+			},
+
+			"api_log_file": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("VCD_API_LOG", nil),
+				Description: "If set, the requests sent to vCD and their responses are appended to this file, with passwords, shared secrets and session tokens redacted.",
+			},
+
+			"api_log_methods": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("VCD_API_LOG_METHODS", nil),
+				Description: "Comma separated HTTP methods to log, such as POST,PUT,DELETE. All are logged if empty.",
+			},
+
+			"api_log_url": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("VCD_API_LOG_URL", nil),
+				Description: "Only log requests whose URL matches this regular expression.",
+			},
+
+			"api_log_status": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("VCD_API_LOG_STATUS", nil),
+				Description:  "Comma separated status codes or classes of responses to log, such as 4xx,5xx. All are logged if empty.",
+				ValidateFunc: validateAPILogStatus,
+			},
 		},
 
 		ResourcesMap: map[string]*schema.Resource{
-			"vcd_network":         resourceVcdNetwork(),
-			"vcd_vapp":            resourceVcdVApp(),
-			"vcd_firewall_rules":  resourceVcdFirewallRules(),
-			"vcd_dnat":            resourceVcdDNAT(),
-			"vcd_snat":            resourceVcdSNAT(),
-			"vcd_edgegateway_vpn": resourceVcdEdgeGatewayVpn(),
-			"vcd_vapp_vm":         resourceVcdVAppVm(),
-			"vcd_org":             resourceOrg(),
+			"vcd_network":              resourceVcdNetwork(),
+			"vcd_vapp":                 resourceVcdVApp(),
+			"vcd_firewall_rules":       resourceVcdFirewallRules(),
+			"vcd_dnat":                 resourceVcdDNAT(),
+			"vcd_snat":                 resourceVcdSNAT(),
+			"vcd_edgegateway_vpn":      resourceVcdEdgeGatewayVpn(),
+			"vcd_vapp_vm":              resourceVcdVAppVm(),
+			"vcd_org":                  resourceOrg(),
+			"vcd_external_network":     resourceVcdExternalNetwork(),
+			"vcd_org_user":             resourceVcdOrgUser(),
+			"vcd_org_role":             resourceVcdOrgRole(),
+			"vcd_org_group":            resourceVcdOrgGroup(),
+			"vcd_catalog_access":       resourceVcdCatalogAccess(),
+			"vcd_vapp_access":          resourceVcdVAppAccess(),
+			"vcd_vdc_access":           resourceVcdVdcAccess(),
+			"vcd_catalog_item_capture": resourceVcdCatalogItemCapture(),
+		},
+
+		DataSourcesMap: map[string]*schema.Resource{
+			"vcd_provider_vdc":  datasourceVcdProviderVdc(),
+			"vcd_right":         datasourceVcdRight(),
+			"vcd_query":         datasourceVcdQuery(),
+			"vcd_vms":           datasourceVcdVms(),
+			"vcd_vapps":         datasourceVcdVApps(),
+			"vcd_vapp":          datasourceVcdVApp(),
+			"vcd_vapp_vm":       datasourceVcdVAppVm(),
+			"vcd_vapp_template": datasourceVcdVAppTemplate(),
 		},
 
 		ConfigureFunc: providerConfigure,
@@ -84,11 +188,41 @@ func providerConfigure(d *schema.ResourceData) (interface{}, error) {
 	config := Config{
 		User:            d.Get("user").(string),
 		Password:        d.Get("password").(string),
+		Token:           d.Get("token").(string),
+		AuthType:        d.Get("auth_type").(string),
+		SysOrg:          d.Get("sysorg").(string),
 		Org:             d.Get("org").(string),
+		Vdc:             d.Get("vdc").(string),
 		Href:            d.Get("url").(string),
 		MaxRetryTimeout: maxRetryTimeout,
 		InsecureFlag:    d.Get("allow_unverified_ssl").(bool),
+		CacheToken:      d.Get("cache_token").(bool),
+		APIVersion:      d.Get("api_version").(string),
+		CacheLookups:    d.Get("cache_lookups").(bool),
+		APILogFile:      d.Get("api_log_file").(string),
+		APILogMethods:   d.Get("api_log_methods").(string),
+		APILogURL:       d.Get("api_log_url").(string),
+		APILogStatuses:  d.Get("api_log_status").(string),
 	}
 
 	return config.Client()
 }
+
+func validateAuthType(v interface{}, k string) ([]string, []error) {
+	switch v.(string) {
+	case "password", "token", "saml_adfs":
+		return nil, nil
+	}
+	return nil, []error{fmt.Errorf("%s must be one of password, token or saml_adfs, got %s", k, v.(string))}
+}
+
+var apiLogStatus = regexp.MustCompile(`^[1-5]([0-9]{2}|xx)$`)
+
+func validateAPILogStatus(v interface{}, k string) ([]string, []error) {
+	for _, status := range splitLogFilter(v.(string)) {
+		if !apiLogStatus.MatchString(strings.ToLower(status)) {
+			return nil, []error{fmt.Errorf("%s must list status codes such as 404 or classes such as 5xx, got %s", k, status)}
+		}
+	}
+	return nil, nil
+}
@@ -1,9 +1,12 @@
 package vcd
 
 import (
+	"flag"
+	"fmt"
 	"os"
 	"testing"
 
+	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/terraform"
 )
@@ -13,6 +16,12 @@ var testAccProvider *schema.Provider
 var testOrg = os.Getenv("VCD_TEST_ORG")
 var testVDC = os.Getenv("VCD_VDC")
 
+// Set when the tests run against the in-process mock vCD
+var testMockVCD *mockVCD
+
+// Set when the tests record or replay cassettes
+var testCassettes *cassetteRecorder
+
 func init() {
 	testAccProvider = Provider().(*schema.Provider)
 	testAccProviders = map[string]terraform.ResourceProvider{
@@ -20,6 +29,110 @@ func init() {
 	}
 }
 
+// TestMain runs the tests against an in-process mock vCD when VCD_URL
+// isn't set, so that the acceptance tests it can serve run hermetically
+// with TF_ACC alone. With VCD_CASSETTE_MODE, it records the vCD traffic
+// of the tests or replays it instead.
+func TestMain(m *testing.M) {
+	var err error
+	testCassettes, err = newCassetteRecorder(os.Getenv(cassetteModeEnv))
+	if err == nil && testCassettes != nil && !testCassettes.recording {
+		err = testCassettes.loadEnvironment()
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	if testCassettes != nil {
+		wrapTransport = testCassettes.wrap
+	}
+
+	if os.Getenv("VCD_URL") == "" {
+		testMockVCD = newMockVCD()
+		setTestEnv(map[string]string{
+			"VCD_URL":                  testMockVCD.URL(),
+			"VCD_USER":                 mockVCDUser,
+			"VCD_PASSWORD":             mockVCDPassword,
+			"VCD_ORG":                  mockVCDOrg,
+			"VCD_TEST_ORG":             mockVCDOrg,
+			"VCD_VDC":                  mockVCDVdc,
+			"VCD_EDGE_GATEWAY":         mockVCDEdge,
+			"VCD_EXTERNAL_IP":          mockVCDExternalIP,
+			"VCD_ALLOW_UNVERIFIED_SSL": "true",
+		})
+	}
+	testOrg = os.Getenv("VCD_TEST_ORG")
+	testVDC = os.Getenv("VCD_VDC")
+
+	if testCassettes != nil && testCassettes.recording {
+		if err := testCassettes.saveEnvironment(); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	}
+
+	// With -sweep, the sweepers run instead of the tests
+	code := 0
+	flag.Parse()
+	if flag.Lookup("sweep").Value.String() != "" {
+		resource.TestMain(m)
+	} else {
+		code = m.Run()
+	}
+	if testMockVCD != nil {
+		testMockVCD.close()
+	}
+	os.Exit(code)
+}
+
+func setTestEnv(env map[string]string) {
+	for key, value := range env {
+		os.Setenv(key, value)
+	}
+}
+
+// testAccSkipOnMock skips tests that need parts of vCD the mock doesn't
+// implement, such as composing vApps from catalog templates or the admin
+// API.
+func testAccSkipOnMock(t *testing.T, reason string) {
+	if testMockVCD != nil {
+		t.Skipf("Not supported by the mock vCD: %s. Set VCD_URL to run against vCD", reason)
+	}
+}
+
+// testAccMockObjects adds vApps with VMs to the mock vCD, and vApp
+// templates with VMs to its catalog, mockVCDCatalog, for data source
+// tests. Against vCD the tests are skipped, as the objects would have to
+// be built from catalog templates.
+func testAccMockObjects(t *testing.T, vApps, templates map[string][]string) {
+	testAccPreCheck(t)
+	if testMockVCD == nil {
+		t.Skip("Needs vApps and vApp templates added to the mock vCD")
+	}
+	for name, vms := range vApps {
+		testMockVCD.addVApp(name, vms...)
+	}
+	for name, vms := range templates {
+		testMockVCD.addVAppTemplate(name, vms...)
+	}
+}
+
+// testAccVAppTemplate returns the catalog and the vApp template that
+// tests building their vApps through resources instantiate. Against vCD
+// they are VCD_CATALOG and VCD_TEMPLATE, and the test is skipped without
+// them. On the mock vCD, name is added to mockVCDCatalog with a VM.
+func testAccVAppTemplate(t *testing.T, name string) (catalog, template string) {
+	if testMockVCD != nil {
+		testMockVCD.addVAppTemplate(name, name+"-vm")
+		return mockVCDCatalog, name
+	}
+	catalog, template = os.Getenv("VCD_CATALOG"), os.Getenv("VCD_TEMPLATE")
+	if catalog == "" || template == "" {
+		t.Skip("Environment variables VCD_CATALOG and VCD_TEMPLATE must be set to instantiate vApps")
+	}
+	return catalog, template
+}
+
 func TestProvider(t *testing.T) {
 	if err := Provider().(*schema.Provider).InternalValidate(); err != nil {
 		t.Fatalf("err: %s", err)
@@ -30,7 +143,16 @@ func TestProvider_impl(t *testing.T) {
 	var _ terraform.ResourceProvider = Provider()
 }
 
+// testAccCassette records or replays the vCD traffic of test t, when
+// the tests use cassettes.
+func testAccCassette(t *testing.T) {
+	if testCassettes != nil {
+		testCassettes.start(t)
+	}
+}
+
 func testAccPreCheck(t *testing.T) {
+	testAccCassette(t)
 	if v := os.Getenv("VCD_USER"); v == "" {
 		t.Fatal("VCD_USER must be set for acceptance tests")
 	}
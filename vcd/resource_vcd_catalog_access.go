@@ -0,0 +1,105 @@
+package vcd
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	govcd "github.com/vmware/go-vcloud-director/govcd"
+	types "github.com/vmware/go-vcloud-director/types/v56"
+)
+
+func resourceVcdCatalogAccess() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceVcdCatalogAccessUpdate,
+		Read:   resourceVcdCatalogAccessRead,
+		Update: resourceVcdCatalogAccessUpdate,
+		Delete: resourceVcdCatalogAccessDelete,
+
+		Schema: accessControlSchema(map[string]*schema.Schema{
+			"org": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+
+			"catalog": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+		}, validateAccessLevel),
+	}
+}
+
+func resourceVcdCatalogAccessUpdate(d *schema.ResourceData, meta interface{}) error {
+	vcdClient := meta.(*VCDClient)
+
+	catalog, err := getAccessCatalog(d, vcdClient)
+	if err != nil {
+		return err
+	}
+
+	params, err := expandControlAccessParams(d, vcdClient)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Setting access control on catalog %s", d.Get("catalog").(string))
+	_, err = catalog.SetAccessControl(params)
+	if err != nil {
+		return fmt.Errorf("Error setting access control on catalog %s: %#v", d.Get("catalog").(string), err)
+	}
+
+	d.SetId(catalog.Catalog.HREF)
+
+	return resourceVcdCatalogAccessRead(d, meta)
+}
+
+func resourceVcdCatalogAccessRead(d *schema.ResourceData, meta interface{}) error {
+	vcdClient := meta.(*VCDClient)
+
+	catalog, err := getAccessCatalog(d, vcdClient)
+	if err != nil {
+		return err
+	}
+
+	params, err := catalog.GetAccessControl()
+	if err != nil {
+		return fmt.Errorf("Error reading access control of catalog %s: %#v", d.Get("catalog").(string), err)
+	}
+	flattenControlAccessParams(d, params)
+
+	vcdClient.setOrgName(d)
+
+	return nil
+}
+
+func resourceVcdCatalogAccessDelete(d *schema.ResourceData, meta interface{}) error {
+	vcdClient := meta.(*VCDClient)
+
+	catalog, err := getAccessCatalog(d, vcdClient)
+	if err != nil {
+		return err
+	}
+
+	_, err = catalog.SetAccessControl(&types.ControlAccessParams{IsSharedToEveryone: false})
+	if err != nil {
+		return fmt.Errorf("Error removing access control from catalog %s: %#v", d.Get("catalog").(string), err)
+	}
+
+	return nil
+}
+
+func getAccessCatalog(d *schema.ResourceData, vcdClient *VCDClient) (govcd.Catalog, error) {
+	org, err := vcdClient.getOrgFromResource(d)
+	if err != nil {
+		return govcd.Catalog{}, err
+	}
+	catalog, err := vcdClient.getCatalog(org, d.Get("catalog").(string))
+	if err != nil || catalog == (govcd.Catalog{}) {
+		return govcd.Catalog{}, fmt.Errorf("Could not find catalog: %s with error %v", d.Get("catalog").(string), err)
+	}
+	return catalog, nil
+}
@@ -0,0 +1,78 @@
+package vcd
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	govcd "github.com/vmware/go-vcloud-director/govcd"
+)
+
+func TestAccVcdCatalogAccess_Basic(t *testing.T) {
+	catalog := os.Getenv("VCD_CATALOG")
+	if catalog == "" || os.Getenv("VCD_SHARE_ORG") == "" {
+		t.Skip("Environment variables VCD_CATALOG and VCD_SHARE_ORG must be set to run catalog access tests")
+		return
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckVcdCatalogAccessDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckVcdCatalogAccess_basic, testOrg, catalog, os.Getenv("VCD_SHARE_ORG")),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"vcd_catalog_access.shared", "everyone", "false"),
+					resource.TestCheckResourceAttr(
+						"vcd_catalog_access.shared", "access.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckVcdCatalogAccessDestroy(s *terraform.State) error {
+	conn := testAccProvider.Meta().(*VCDClient)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "vcd_catalog_access" {
+			continue
+		}
+
+		org, err := govcd.GetOrgByName(conn.VCDClient, rs.Primary.Attributes["org"])
+		if err != nil || org == (govcd.Org{}) {
+			return fmt.Errorf("Could not find test Org")
+		}
+		catalog, err := org.FindCatalog(rs.Primary.Attributes["catalog"])
+		if err != nil || catalog == (govcd.Catalog{}) {
+			return fmt.Errorf("Could not find test catalog")
+		}
+
+		params, err := catalog.GetAccessControl()
+		if err != nil {
+			return fmt.Errorf("Could not read access control of catalog: %#v", err)
+		}
+		if params.AccessSettings != nil && len(params.AccessSettings.AccessSetting) > 0 {
+			return fmt.Errorf("Catalog %s is still shared", rs.Primary.Attributes["catalog"])
+		}
+	}
+
+	return nil
+}
+
+const testAccCheckVcdCatalogAccess_basic = `
+resource "vcd_catalog_access" "shared" {
+  org     = "%s"
+  catalog = "%s"
+
+  access {
+    subject_type = "org"
+    subject_name = "%s"
+    access_level = "ReadOnly"
+  }
+}
+`
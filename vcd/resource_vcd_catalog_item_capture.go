@@ -0,0 +1,216 @@
+package vcd
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	govcd "github.com/vmware/go-vcloud-director/govcd"
+)
+
+func resourceVcdCatalogItemCapture() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceVcdCatalogItemCaptureCreate,
+		Read:   resourceVcdCatalogItemCaptureRead,
+		Delete: resourceVcdCatalogItemCaptureDelete,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(60 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"org": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+
+			"vdc": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+
+			"catalog_name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"name": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The name of the catalog item, which vcd_vapp takes as template_name",
+			},
+
+			"description": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"vapp_name": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The vApp to capture",
+			},
+
+			"vm_name": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "A VM to capture, which must be the only VM of its vApp as vCD captures whole vApps",
+			},
+
+			"customize_on_instantiate": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     false,
+				Description: "Customize the VMs of the template when it is instantiated",
+			},
+
+			"href": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"template_href": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceVcdCatalogItemCaptureCreate(d *schema.ResourceData, meta interface{}) error {
+	vcdClient := meta.(*VCDClient)
+	ctx, cancel := operationContext(d, schema.TimeoutCreate)
+	defer cancel()
+
+	_, vdc, err := vcdClient.getOrgAndVdc(d)
+	if err != nil {
+		return err
+	}
+
+	catalog, err := getCaptureCatalog(d, vcdClient)
+	if err != nil {
+		return err
+	}
+
+	vapp, err := vdc.FindVAppByName(d.Get("vapp_name").(string))
+	if err != nil {
+		return fmt.Errorf("error finding vapp: %s", err)
+	}
+
+	if vmName, ok := d.GetOk("vm_name"); ok {
+		if _, err := vdc.FindVMByName(vapp, vmName.(string)); err != nil {
+			return fmt.Errorf("error finding vm: %s", err)
+		}
+		if vapp.VApp.Children != nil && len(vapp.VApp.Children.VM) > 1 {
+			return fmt.Errorf("vm %s shares vapp %s with other VMs, and vCD captures whole vApps", vmName.(string), vapp.VApp.Name)
+		}
+	}
+
+	name := d.Get("name").(string)
+	log.Printf("[INFO] Capturing vapp %s to catalog %s as %s", vapp.VApp.Name, catalog.Catalog.Name, name)
+	err = retryCallContext(ctx, func() *resource.RetryError {
+		task, err := catalog.CaptureVApp(vapp, name, d.Get("description").(string), d.Get("customize_on_instantiate").(bool))
+		if err != nil {
+			return retryOnTransientError(fmt.Errorf("error capturing vapp: %w", err))
+		}
+		return retryOnTransientError(task.WaitTaskCompletionContext(ctx))
+	})
+	// The cached catalog lists its items, without the new one
+	vcdClient.invalidateCatalog(catalog)
+	if err != nil {
+		return fmt.Errorf("error capturing vapp %s: %s", vapp.VApp.Name, err)
+	}
+
+	catalog, err = getCaptureCatalog(d, vcdClient)
+	if err != nil {
+		return err
+	}
+	catalogItem, err := catalog.FindCatalogItem(name)
+	if err != nil {
+		return fmt.Errorf("error finding captured catalog item: %s", err)
+	}
+	d.SetId(catalogItem.CatalogItem.HREF)
+
+	return resourceVcdCatalogItemCaptureRead(d, meta)
+}
+
+func resourceVcdCatalogItemCaptureRead(d *schema.ResourceData, meta interface{}) error {
+	vcdClient := meta.(*VCDClient)
+
+	catalog, err := getCaptureCatalog(d, vcdClient)
+	if err != nil {
+		return err
+	}
+
+	catalogItem, err := catalog.FindCatalogItemByHREF(d.Id())
+	if isNotFoundError(err) {
+		log.Printf("[DEBUG] Catalog item no longer exists. Removing from tfstate")
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("error reading catalog item %s: %s", d.Get("name").(string), err)
+	}
+
+	d.Set("name", catalogItem.CatalogItem.Name)
+	d.Set("href", catalogItem.CatalogItem.HREF)
+	if catalogItem.CatalogItem.Entity != nil {
+		d.Set("template_href", catalogItem.CatalogItem.Entity.HREF)
+	}
+	vcdClient.setOrgAndVdcNames(d)
+
+	return nil
+}
+
+func resourceVcdCatalogItemCaptureDelete(d *schema.ResourceData, meta interface{}) error {
+	vcdClient := meta.(*VCDClient)
+
+	catalog, err := getCaptureCatalog(d, vcdClient)
+	if err != nil {
+		return err
+	}
+
+	// The item may have been deleted outside of Terraform since the last
+	// refresh
+	catalogItem, err := catalog.FindCatalogItemByHREF(d.Id())
+	if isNotFoundError(err) {
+		log.Printf("[DEBUG] Catalog item %s already deleted", d.Id())
+		vcdClient.invalidateCatalog(catalog)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("error finding catalog item: %s", err)
+	}
+
+	err = catalogItem.Delete()
+	vcdClient.invalidateCatalog(catalog)
+	if isNotFoundError(err) {
+		return nil
+	}
+	return err
+}
+
+func getCaptureCatalog(d *schema.ResourceData, vcdClient *VCDClient) (govcd.Catalog, error) {
+	org, err := vcdClient.getOrgFromResource(d)
+	if err != nil {
+		return govcd.Catalog{}, err
+	}
+	catalogName := d.Get("catalog_name").(string)
+	catalog, err := vcdClient.getCatalog(org, catalogName)
+	if err != nil || catalog == (govcd.Catalog{}) {
+		return govcd.Catalog{}, fmt.Errorf("could not find catalog %s: %v", catalogName, err)
+	}
+	return catalog, nil
+}
@@ -0,0 +1,144 @@
+package vcd
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccVcdCatalogItemCapture_Basic(t *testing.T) {
+	var href string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccMockObjects(t, map[string][]string{
+				"tf-acc-golden": {"tf-acc-golden-vm"},
+			}, nil)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckVcdCatalogItemCaptureDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckVcdCatalogItemCapture_basic, testOrg, testVDC, mockVCDCatalog, testOrg, testVDC),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(
+						"vcd_catalog_item_capture.golden", "href"),
+					resource.TestCheckResourceAttrSet(
+						"vcd_catalog_item_capture.golden", "template_href"),
+					resource.TestCheckResourceAttr(
+						"vcd_vapp.from_golden", "vms.#", "1"),
+					resource.TestCheckResourceAttr(
+						"vcd_vapp.from_golden", "vms.0.name", "tf-acc-golden-vm"),
+					func(s *terraform.State) error {
+						href = s.RootModule().Resources["vcd_catalog_item_capture.golden"].Primary.ID
+						return nil
+					},
+				),
+			},
+			// The item is captured again after being deleted outside of
+			// Terraform
+			resource.TestStep{
+				PreConfig: func() {
+					testAccDeleteCatalogItem(t, href)
+				},
+				Config: fmt.Sprintf(testAccCheckVcdCatalogItemCapture_basic, testOrg, testVDC, mockVCDCatalog, testOrg, testVDC),
+				Check: resource.ComposeTestCheckFunc(
+					func(s *terraform.State) error {
+						if s.RootModule().Resources["vcd_catalog_item_capture.golden"].Primary.ID == href {
+							return fmt.Errorf("catalog item %s was not captured again", href)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+// Destroy refreshes first, so the acceptance test can't delete the item
+// between the refresh and the delete.
+func TestVcdCatalogItemCaptureDelete_Gone(t *testing.T) {
+	if testMockVCD == nil {
+		t.Skip("Only runs against the mock vCD")
+	}
+	client, _ := testMockVCDClient(t)
+	d := schema.TestResourceDataRaw(t, resourceVcdCatalogItemCapture().Schema, map[string]interface{}{
+		"org":          mockVCDOrg,
+		"vdc":          mockVCDVdc,
+		"catalog_name": mockVCDCatalog,
+		"name":         "tf-acc-gone-template",
+	})
+	d.SetId(testMockVCD.href("/catalogItem/gone"))
+
+	if err := resourceVcdCatalogItemCaptureDelete(d, client); err != nil {
+		t.Fatalf("error deleting a catalog item already gone: %s", err)
+	}
+}
+
+func testAccDeleteCatalogItem(t *testing.T, href string) {
+	conn := testAccProvider.Meta().(*VCDClient)
+	org, err := conn.getOrg(testOrg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	catalog, err := conn.getCatalog(org, mockVCDCatalog)
+	if err != nil {
+		t.Fatal(err)
+	}
+	catalogItem, err := catalog.FindCatalogItemByHREF(href)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := catalogItem.Delete(); err != nil {
+		t.Fatal(err)
+	}
+	conn.invalidateCatalog(catalog)
+}
+
+func testAccCheckVcdCatalogItemCaptureDestroy(s *terraform.State) error {
+	conn := testAccProvider.Meta().(*VCDClient)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "vcd_catalog_item_capture" {
+			continue
+		}
+		org, err := conn.getOrg(testOrg)
+		if err != nil {
+			return err
+		}
+		// Looked up again, as the cached catalog may list the deleted item
+		catalog, err := org.FindCatalog(rs.Primary.Attributes["catalog_name"])
+		if err != nil {
+			return err
+		}
+		if _, err := catalog.FindCatalogItem(rs.Primary.Attributes["name"]); err == nil {
+			return fmt.Errorf("catalog item %s still exists", rs.Primary.Attributes["name"])
+		}
+	}
+	return nil
+}
+
+const testAccCheckVcdCatalogItemCapture_basic = `
+resource "vcd_catalog_item_capture" "golden" {
+  org                      = "%s"
+  vdc                      = "%s"
+  catalog_name             = "%s"
+  name                     = "tf-acc-golden-template"
+  vapp_name                = "tf-acc-golden"
+  vm_name                  = "tf-acc-golden-vm"
+  customize_on_instantiate = true
+}
+
+resource "vcd_vapp" "from_golden" {
+  org           = "%s"
+  vdc           = "%s"
+  name          = "tf-acc-from-golden"
+  catalog_name  = "${vcd_catalog_item_capture.golden.catalog_name}"
+  template_name = "${vcd_catalog_item_capture.golden.name}"
+  instantiate   = true
+  power_on      = false
+}
+`
@@ -2,10 +2,11 @@ package vcd
 
 import (
 	"fmt"
+	"time"
 
-	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/helper/schema"
 	govcd "github.com/vmware/go-vcloud-director/govcd"
+	types "github.com/vmware/go-vcloud-director/types/v56"
 )
 
 func resourceVcdDNAT() *schema.Resource {
@@ -14,6 +15,11 @@ func resourceVcdDNAT() *schema.Resource {
 		Delete: resourceVcdDNATDelete,
 		Read:   resourceVcdDNATRead,
 
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+
 		Schema: map[string]*schema.Schema{
 			"edge_gateway": &schema.Schema{
 				Type:     schema.TypeString,
@@ -22,12 +28,14 @@ func resourceVcdDNAT() *schema.Resource {
 			},
 			"org": {
 				Type:     schema.TypeString,
-				Required: true,
+				Optional: true,
+				Computed: true,
 				ForceNew: true,
 			},
 			"vdc": {
 				Type:     schema.TypeString,
-				Required: true,
+				Optional: true,
+				Computed: true,
 				ForceNew: true,
 			},
 			"external_ip": &schema.Schema{
@@ -53,61 +61,50 @@ func resourceVcdDNAT() *schema.Resource {
 				Required: true,
 				ForceNew: true,
 			},
+
+			"description": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
 		},
 	}
 }
 
 func resourceVcdDNATCreate(d *schema.ResourceData, meta interface{}) error {
 	vcdClient := meta.(*VCDClient)
-	org, err := govcd.GetOrgByName(vcdClient.VCDClient, d.Get("org").(string))
+	ctx, cancel := operationContext(d, schema.TimeoutCreate)
+	defer cancel()
+
+	_, vdc, err := vcdClient.getOrgAndVdc(d)
 	if err != nil {
-		return fmt.Errorf("Could not get Org: %s with error %v", d.Get("org").(string), err)
-	}
-	if org == (govcd.Org{}) {
-		return fmt.Errorf("Could not find Org: %s", d.Get("org").(string))
-	}
-	vdc, err := org.GetVdcByName(d.Get("vdc").(string))
-	if err != nil || vdc == (govcd.Vdc{}) {
-		return fmt.Errorf("Could not get vdc: %s with error %v", d.Get("vdc").(string), err)
-	}
-	if vdc == (govcd.Vdc{}) {
-		return fmt.Errorf("Could not find vdc: %s", d.Get("vdc").(string))
+		return err
 	}
 
-	// Multiple VCD components need to run operations on the Edge Gateway, as
-	// the edge gatway will throw back an error if it is already performing an
-	// operation we must wait until we can aquire a lock on the client
-	vcdClient.Mutex.Lock()
-	defer vcdClient.Mutex.Unlock()
 	portString := getPortString(d.Get("port").(int))
 	translatedPortString := portString // default
 	if d.Get("translated_port").(int) > 0 {
 		translatedPortString = getPortString(d.Get("translated_port").(int))
 	}
 
-	edgeGateway, err := vdc.FindEdgeGateway(d.Get("edge_gateway").(string))
+	edgeGateway, err := vcdClient.getEdgeGateway(vdc, d.Get("edge_gateway").(string))
 
 	if err != nil {
 		return fmt.Errorf("Unable to find edge gateway: %#v", err)
 	}
 
-	// Creating a loop to offer further protection from the edge gateway erroring
-	// due to being busy eg another person is using another client so wouldn't be
-	// constrained by out lock. If the edge gateway reurns with a busy error, wait
-	// 3 seconds and then try again. Continue until a non-busy error or success
-
-	err = retryCall(vcdClient.MaxRetryTimeout, func() *resource.RetryError {
-		task, err := edgeGateway.AddNATPortMapping("DNAT",
+	// Multiple VCD components need to run operations on the Edge Gateway, as
+	// the edge gatway will throw back an error if it is already performing an
+	// operation the rule is queued and submitted together with the other
+	// changes to the gateway
+	err = vcdClient.configureEdgeGateway(ctx, edgeGateway, func(edgeGateway *govcd.EdgeGateway, config *types.EdgeGatewayServiceConfiguration) error {
+		edgeGateway.AddNATPortMappingToConfig(config, "DNAT",
 			d.Get("external_ip").(string),
 			portString,
 			d.Get("internal_ip").(string),
-			translatedPortString)
-		if err != nil {
-			return resource.RetryableError(
-				fmt.Errorf("Error setting DNAT rules: %#v", err))
-		}
-
-		return resource.RetryableError(task.WaitTaskCompletion())
+			translatedPortString,
+			d.Get("description").(string))
+		return nil
 	})
 
 	if err != nil {
@@ -115,28 +112,19 @@ func resourceVcdDNATCreate(d *schema.ResourceData, meta interface{}) error {
 	}
 
 	d.SetId(d.Get("external_ip").(string) + ":" + portString + " > " + d.Get("internal_ip").(string) + ":" + translatedPortString)
+	vcdClient.setOrgAndVdcNames(d)
 	return nil
 }
 
 func resourceVcdDNATRead(d *schema.ResourceData, meta interface{}) error {
 	vcdClient := meta.(*VCDClient)
 
-	org, err := govcd.GetOrgByName(vcdClient.VCDClient, d.Get("org").(string))
+	_, vdc, err := vcdClient.getOrgAndVdc(d)
 	if err != nil {
-		return fmt.Errorf("Could not get Org: %s with error %v", d.Get("org").(string), err)
-	}
-	if org == (govcd.Org{}) {
-		return fmt.Errorf("Could not find Org: %s", d.Get("org").(string))
-	}
-	vdc, err := org.GetVdcByName(d.Get("vdc").(string))
-	if err != nil || vdc == (govcd.Vdc{}) {
-		return fmt.Errorf("Could not get vdc: %s with error %v", d.Get("vdc").(string), err)
-	}
-	if vdc == (govcd.Vdc{}) {
-		return fmt.Errorf("Could not find vdc: %s", d.Get("vdc").(string))
+		return err
 	}
 
-	e, err := vdc.FindEdgeGateway(d.Get("edge_gateway").(string))
+	e, err := vcdClient.getEdgeGateway(vdc, d.Get("edge_gateway").(string))
 
 	if err != nil {
 		return fmt.Errorf("Unable to find edge gateway: %#v", err)
@@ -150,6 +138,7 @@ func resourceVcdDNATRead(d *schema.ResourceData, meta interface{}) error {
 			r.GatewayNatRule.OriginalPort == getPortString(d.Get("port").(int)) {
 			found = true
 			d.Set("internal_ip", r.GatewayNatRule.TranslatedIP)
+			d.Set("description", r.Description)
 		}
 	}
 
@@ -157,54 +146,34 @@ func resourceVcdDNATRead(d *schema.ResourceData, meta interface{}) error {
 		d.SetId("")
 	}
 
+	vcdClient.setOrgAndVdcNames(d)
+
 	return nil
 }
 
 func resourceVcdDNATDelete(d *schema.ResourceData, meta interface{}) error {
 	vcdClient := meta.(*VCDClient)
-	org, err := govcd.GetOrgByName(vcdClient.VCDClient, d.Get("org").(string))
+	ctx, cancel := operationContext(d, schema.TimeoutDelete)
+	defer cancel()
+
+	_, vdc, err := vcdClient.getOrgAndVdc(d)
 	if err != nil {
-		return fmt.Errorf("Could not get Org: %s with error %v", d.Get("org").(string), err)
-	}
-	if org == (govcd.Org{}) {
-		return fmt.Errorf("Could not find Org: %s", d.Get("org").(string))
-	}
-	vdc, err := org.GetVdcByName(d.Get("vdc").(string))
-	if err != nil || vdc == (govcd.Vdc{}) {
-		return fmt.Errorf("Could not get vdc: %s with error %v", d.Get("vdc").(string), err)
-	}
-	if vdc == (govcd.Vdc{}) {
-		return fmt.Errorf("Could not find vdc: %s", d.Get("vdc").(string))
+		return err
 	}
 
-	// Multiple VCD components need to run operations on the Edge Gateway, as
-	// the edge gatway will throw back an error if it is already performing an
-	// operation we must wait until we can aquire a lock on the client
-	vcdClient.Mutex.Lock()
-	defer vcdClient.Mutex.Unlock()
 	portString := getPortString(d.Get("port").(int))
-	translatedPortString := portString // default
-	if d.Get("translated_port").(int) > 0 {
-		translatedPortString = getPortString(d.Get("translated_port").(int))
-	}
 
-	edgeGateway, err := vdc.FindEdgeGateway(d.Get("edge_gateway").(string))
+	edgeGateway, err := vcdClient.getEdgeGateway(vdc, d.Get("edge_gateway").(string))
 
 	if err != nil {
 		return fmt.Errorf("Unable to find edge gateway: %#v", err)
 	}
-	err = retryCall(vcdClient.MaxRetryTimeout, func() *resource.RetryError {
-		task, err := edgeGateway.RemoveNATPortMapping("DNAT",
-			d.Get("external_ip").(string),
-			portString,
-			d.Get("internal_ip").(string),
-			translatedPortString)
-		if err != nil {
-			return resource.RetryableError(
-				fmt.Errorf("Error setting DNAT rules: %#v", err))
-		}
 
-		return resource.RetryableError(task.WaitTaskCompletion())
+	err = vcdClient.configureEdgeGateway(ctx, edgeGateway, func(edgeGateway *govcd.EdgeGateway, config *types.EdgeGatewayServiceConfiguration) error {
+		edgeGateway.RemoveNATPortMappingFromConfig(config, "DNAT",
+			d.Get("external_ip").(string),
+			portString)
+		return nil
 	})
 	if err != nil {
 		return fmt.Errorf("Error completing tasks: %#v", err)
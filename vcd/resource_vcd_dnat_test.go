@@ -8,8 +8,24 @@ import (
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
 	govcd "github.com/vmware/go-vcloud-director/govcd"
+	types "github.com/vmware/go-vcloud-director/types/v56"
 )
 
+func init() {
+	resource.AddTestSweepers("vcd_dnat", &resource.Sweeper{
+		Name: "vcd_dnat",
+		F:    testSweepVcdDNAT,
+	})
+}
+
+func testSweepVcdDNAT(org string) error {
+	return sweepEdgeGateway(org, "DNAT rules", func(edgeGateway *govcd.EdgeGateway, config *types.EdgeGatewayServiceConfiguration) int {
+		natService, removed := sweepNatRules(natServiceToSweep(edgeGateway, config), "DNAT")
+		config.NatService = natService
+		return removed
+	})
+}
+
 func TestAccVcdDNAT_Basic(t *testing.T) {
 	if v := os.Getenv("VCD_EXTERNAL_IP"); v == "" {
 		t.Skip("Environment variable VCD_EXTERNAL_IP must be set to run DNAT tests")
@@ -32,6 +48,8 @@ func TestAccVcdDNAT_Basic(t *testing.T) {
 						"vcd_dnat.bar", "port", "7777"),
 					resource.TestCheckResourceAttr(
 						"vcd_dnat.bar", "internal_ip", "10.10.102.60"),
+					resource.TestCheckResourceAttr(
+						"vcd_dnat.bar", "description", "tf-acc-dnat"),
 				),
 			},
 		},
@@ -43,7 +61,6 @@ func TestAccVcdDNAT_tlate(t *testing.T) {
 		t.Skip("Environment variable VCD_EXTERNAL_IP must be set to run DNAT tests")
 		return
 	}
-	fmt.Printf(testOrg)
 	var e govcd.EdgeGateway
 
 	resource.Test(t, resource.TestCase{
@@ -61,6 +78,8 @@ func TestAccVcdDNAT_tlate(t *testing.T) {
 						"vcd_dnat.bar", "port", "7777"),
 					resource.TestCheckResourceAttr(
 						"vcd_dnat.bar", "internal_ip", "10.10.102.60"),
+					resource.TestCheckResourceAttr(
+						"vcd_dnat.bar", "description", "tf-acc-dnat"),
 					resource.TestCheckResourceAttr(
 						"vcd_dnat.bar", "translated_port", "77"),
 				),
@@ -130,7 +149,6 @@ func testAccCheckVcdDNATtlateExists(n string, gateway *govcd.EdgeGateway) resour
 		conn := testAccProvider.Meta().(*VCDClient)
 
 		gatewayName := rs.Primary.Attributes["edge_gateway"]
-		fmt.Printf(testOrg)
 		org, err := govcd.GetOrgByName(conn.VCDClient, testOrg)
 		if err != nil {
 			return fmt.Errorf("Could not find test Org")
@@ -213,6 +231,7 @@ resource "vcd_dnat" "bar" {
 	external_ip = "%s"
 	port = 7777
 	internal_ip = "10.10.102.60"
+	description = "tf-acc-dnat"
 }
 `
 const testAccCheckVcdDnat_tlate = `
@@ -223,6 +242,7 @@ resource "vcd_dnat" "bar" {
 	external_ip = "%s"
 	port = 7777
 	internal_ip = "10.10.102.60"
+	description = "tf-acc-dnat"
 	translated_port = 77
 }
 `
@@ -2,11 +2,11 @@ package vcd
 
 import (
 	"fmt"
-	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/helper/schema"
 	govcd "github.com/vmware/go-vcloud-director/govcd"
 	types "github.com/vmware/go-vcloud-director/types/v56"
 	"log"
+	"time"
 )
 
 func resourceVcdEdgeGatewayVpn() *schema.Resource {
@@ -15,6 +15,11 @@ func resourceVcdEdgeGatewayVpn() *schema.Resource {
 		Read:   resourceVcdEdgeGatewayVpnRead,
 		Delete: resourceVcdEdgeGatewayVpnDelete,
 
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+
 		Schema: map[string]*schema.Schema{
 
 			"edge_gateway": &schema.Schema{
@@ -30,12 +35,14 @@ func resourceVcdEdgeGatewayVpn() *schema.Resource {
 			},
 			"org": {
 				Type:     schema.TypeString,
-				Required: true,
+				Optional: true,
+				Computed: true,
 				ForceNew: true,
 			},
 			"vdc": {
 				Type:     schema.TypeString,
-				Required: true,
+				Optional: true,
+				Computed: true,
 				ForceNew: true,
 			},
 			"description": &schema.Schema{
@@ -139,25 +146,18 @@ func resourceVcdEdgeGatewayVpn() *schema.Resource {
 
 func resourceVcdEdgeGatewayVpnCreate(d *schema.ResourceData, meta interface{}) error {
 	vcdClient := meta.(*VCDClient)
-	log.Printf("[TRACE] CLIENT: %#v", vcdClient)
-	org, err := govcd.GetOrgByName(vcdClient.VCDClient, d.Get("org").(string))
+	ctx, cancel := operationContext(d, schema.TimeoutCreate)
+	defer cancel()
+
+	_, vdc, err := vcdClient.getOrgAndVdc(d)
 	if err != nil {
-		return fmt.Errorf("Could not get Org: %s with error %v", d.Get("org").(string), err)
-	}
-	if org == (govcd.Org{}) {
-		return fmt.Errorf("Could not find Org: %s", d.Get("org").(string))
+		return err
 	}
-	vdc, err := org.GetVdcByName(d.Get("vdc").(string))
-	if err != nil || vdc == (govcd.Vdc{}) {
-		return fmt.Errorf("Could not get vdc: %s with error %v", d.Get("vdc").(string), err)
-	}
-	if vdc == (govcd.Vdc{}) {
-		return fmt.Errorf("Could not find vdc: %s", d.Get("vdc").(string))
-	}
-	vcdClient.Mutex.Lock()
-	defer vcdClient.Mutex.Unlock()
 
-	edgeGateway, err := vdc.FindEdgeGateway(d.Get("edge_gateway").(string))
+	edgeGateway, err := vcdClient.getEdgeGateway(vdc, d.Get("edge_gateway").(string))
+	if err != nil {
+		return fmt.Errorf("Unable to find edge gateway: %#v", err)
+	}
 
 	localSubnetsList := d.Get("local_subnets").(*schema.Set).List()
 	peerSubnetsList := d.Get("peer_subnets").(*schema.Set).List()
@@ -215,16 +215,9 @@ func resourceVcdEdgeGatewayVpnCreate(d *schema.ResourceData, meta interface{}) e
 
 	log.Printf("[INFO] ipsecVPNConfig: %#v", ipsecVPNConfig)
 
-	err = retryCall(vcdClient.MaxRetryTimeout, func() *resource.RetryError {
-		edgeGateway.Refresh()
-		task, err := edgeGateway.AddIpsecVPN(ipsecVPNConfig)
-		if err != nil {
-			log.Printf("[INFO] Error setting ipsecVPNConfig rules: %s", err)
-			return resource.RetryableError(
-				fmt.Errorf("Error setting ipsecVPNConfig rules: %#v", err))
-		}
-
-		return resource.RetryableError(task.WaitTaskCompletion())
+	err = vcdClient.configureEdgeGateway(ctx, edgeGateway, func(edgeGateway *govcd.EdgeGateway, config *types.EdgeGatewayServiceConfiguration) error {
+		config.GatewayIpsecVpnService = ipsecVPNConfig.GatewayIpsecVpnService
+		return nil
 	})
 	if err != nil {
 		return fmt.Errorf("Error completing tasks: %#v", err)
@@ -237,28 +230,18 @@ func resourceVcdEdgeGatewayVpnCreate(d *schema.ResourceData, meta interface{}) e
 
 func resourceVcdEdgeGatewayVpnDelete(d *schema.ResourceData, meta interface{}) error {
 	vcdClient := meta.(*VCDClient)
+	ctx, cancel := operationContext(d, schema.TimeoutDelete)
+	defer cancel()
 
-	log.Printf("[TRACE] CLIENT: %#v", vcdClient)
-
-	org, err := govcd.GetOrgByName(vcdClient.VCDClient, d.Get("org").(string))
+	_, vdc, err := vcdClient.getOrgAndVdc(d)
 	if err != nil {
-		return fmt.Errorf("Could not get Org: %s with error %v", d.Get("org").(string), err)
-	}
-	if org == (govcd.Org{}) {
-		return fmt.Errorf("Could not find Org: %s", d.Get("org").(string))
-	}
-	vdc, err := org.GetVdcByName(d.Get("vdc").(string))
-	if err != nil || vdc == (govcd.Vdc{}) {
-		return fmt.Errorf("Could not get vdc: %s with error %v", d.Get("vdc").(string), err)
-	}
-	if vdc == (govcd.Vdc{}) {
-		return fmt.Errorf("Could not find vdc: %s", d.Get("vdc").(string))
+		return err
 	}
 
-	vcdClient.Mutex.Lock()
-	defer vcdClient.Mutex.Unlock()
-
-	edgeGateway, err := vdc.FindEdgeGateway(d.Get("edge_gateway").(string))
+	edgeGateway, err := vcdClient.getEdgeGateway(vdc, d.Get("edge_gateway").(string))
+	if err != nil {
+		return fmt.Errorf("Unable to find edge gateway: %#v", err)
+	}
 
 	ipsecVPNConfig := &types.EdgeGatewayServiceConfiguration{
 		Xmlns: "http://www.vmware.com/vcloud/v1.5",
@@ -269,16 +252,9 @@ func resourceVcdEdgeGatewayVpnDelete(d *schema.ResourceData, meta interface{}) e
 
 	log.Printf("[INFO] ipsecVPNConfig: %#v", ipsecVPNConfig)
 
-	err = retryCall(vcdClient.MaxRetryTimeout, func() *resource.RetryError {
-		edgeGateway.Refresh()
-		task, err := edgeGateway.AddIpsecVPN(ipsecVPNConfig)
-		if err != nil {
-			log.Printf("[INFO] Error setting ipsecVPNConfig rules: %s", err)
-			return resource.RetryableError(
-				fmt.Errorf("Error setting ipsecVPNConfig rules: %#v", err))
-		}
-
-		return resource.RetryableError(task.WaitTaskCompletion())
+	err = vcdClient.configureEdgeGateway(ctx, edgeGateway, func(edgeGateway *govcd.EdgeGateway, config *types.EdgeGatewayServiceConfiguration) error {
+		config.GatewayIpsecVpnService = ipsecVPNConfig.GatewayIpsecVpnService
+		return nil
 	})
 	if err != nil {
 		return fmt.Errorf("Error completing tasks: %#v", err)
@@ -296,22 +272,12 @@ func resourceVcdEdgeGatewayVpnDelete(d *schema.ResourceData, meta interface{}) e
 func resourceVcdEdgeGatewayVpnRead(d *schema.ResourceData, meta interface{}) error {
 	vcdClient := meta.(*VCDClient)
 
-	org, err := govcd.GetOrgByName(vcdClient.VCDClient, d.Get("org").(string))
+	_, vdc, err := vcdClient.getOrgAndVdc(d)
 	if err != nil {
-		return fmt.Errorf("Could not get Org: %s with error %v", d.Get("org").(string), err)
-	}
-	if org == (govcd.Org{}) {
-		return fmt.Errorf("Could not find Org: %s", d.Get("org").(string))
-	}
-	vdc, err := org.GetVdcByName(d.Get("vdc").(string))
-	if err != nil || vdc == (govcd.Vdc{}) {
-		return fmt.Errorf("Could not get vdc: %s with error %v", d.Get("vdc").(string), err)
-	}
-	if vdc == (govcd.Vdc{}) {
-		return fmt.Errorf("Could not find vdc: %s", d.Get("vdc").(string))
+		return err
 	}
 
-	edgeGateway, err := vdc.FindEdgeGateway(d.Get("edge_gateway").(string))
+	edgeGateway, err := vcdClient.getEdgeGateway(vdc, d.Get("edge_gateway").(string))
 	if err != nil {
 		return fmt.Errorf("Error finding edge gateway: %#v", err)
 	}
@@ -340,5 +306,7 @@ func resourceVcdEdgeGatewayVpnRead(d *schema.ResourceData, meta interface{}) err
 		return fmt.Errorf("Multiple tunnels not currently supported")
 	}
 
+	vcdClient.setOrgAndVdcNames(d)
+
 	return nil
 }
@@ -7,8 +7,41 @@ import (
 
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
+	govcd "github.com/vmware/go-vcloud-director/govcd"
+	types "github.com/vmware/go-vcloud-director/types/v56"
 )
 
+func init() {
+	resource.AddTestSweepers("vcd_edgegateway_vpn", &resource.Sweeper{
+		Name: "vcd_edgegateway_vpn",
+		F:    testSweepVcdEdgeGatewayVpn,
+	})
+}
+
+// Removes the tunnels whose name has the test prefix, disabling the
+// service if none are left.
+func testSweepVcdEdgeGatewayVpn(org string) error {
+	return sweepEdgeGateway(org, "VPN tunnels", func(edgeGateway *govcd.EdgeGateway, config *types.EdgeGatewayServiceConfiguration) int {
+		vpnService := config.GatewayIpsecVpnService
+		if vpnService == nil {
+			vpnService = edgeGateway.EdgeGateway.Configuration.EdgeGatewayServiceConfiguration.GatewayIpsecVpnService
+		}
+		if vpnService == nil {
+			return 0
+		}
+		swept := *vpnService
+		swept.Tunnel = nil
+		for _, tunnel := range vpnService.Tunnel {
+			if !isTestAccName(tunnel.Name) {
+				swept.Tunnel = append(swept.Tunnel, tunnel)
+			}
+		}
+		swept.IsEnabled = vpnService.IsEnabled && len(swept.Tunnel) > 0
+		config.GatewayIpsecVpnService = &swept
+		return len(vpnService.Tunnel) - len(swept.Tunnel)
+	})
+}
+
 func TestAccVcdVpn_Basic(t *testing.T) {
 
 	resource.Test(t, resource.TestCase{
@@ -45,7 +78,7 @@ resource "vcd_edgegateway_vpn" "vpn" {
 	org 				= "%s"
 	vdc                 = "%s"
     edge_gateway        = "%s"
-    name                = "west-to-east"
+    name                = "tf-acc-west-to-east"
 	description         = "Description"
 	encryption_protocol = "AES256"
     mtu                 = 1400
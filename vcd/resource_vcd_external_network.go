@@ -0,0 +1,291 @@
+package vcd
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	govcd "github.com/vmware/go-vcloud-director/govcd"
+	types "github.com/vmware/go-vcloud-director/types/v56"
+)
+
+func resourceVcdExternalNetwork() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceVcdExternalNetworkCreate,
+		Read:   resourceVcdExternalNetworkRead,
+		Delete: resourceVcdExternalNetworkDelete,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"description": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"ip_scope": &schema.Schema{
+				Type:     schema.TypeList,
+				Required: true,
+				ForceNew: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"gateway": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+						},
+
+						"netmask": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+						},
+
+						"dns1": &schema.Schema{
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+
+						"dns2": &schema.Schema{
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+
+						"dns_suffix": &schema.Schema{
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+
+						"static_ip_pool": &schema.Schema{
+							Type:     schema.TypeSet,
+							Optional: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"start_address": &schema.Schema{
+										Type:     schema.TypeString,
+										Required: true,
+									},
+
+									"end_address": &schema.Schema{
+										Type:     schema.TypeString,
+										Required: true,
+									},
+								},
+							},
+							Set: resourceVcdNetworkIPAddressHash,
+						},
+					},
+				},
+			},
+
+			"vsphere_network": &schema.Schema{
+				Type:     schema.TypeList,
+				Required: true,
+				ForceNew: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": &schema.Schema{
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Name of the vSphere port group backing the network",
+						},
+
+						"vcenter": &schema.Schema{
+							Type:        schema.TypeString,
+							Optional:    true,
+							Computed:    true,
+							Description: "Name of the vCenter Server that manages the port group",
+						},
+
+						"type": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+
+			"retain_net_info_across_deployments": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				ForceNew: true,
+				Default:  false,
+			},
+
+			"href": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceVcdExternalNetworkCreate(d *schema.ResourceData, meta interface{}) error {
+	vcdClient := meta.(*VCDClient)
+	ctx, cancel := operationContext(d, schema.TimeoutCreate)
+	defer cancel()
+
+	portGroups, err := expandVimPortGroupRefs(vcdClient, d.Get("vsphere_network").([]interface{}))
+	if err != nil {
+		return err
+	}
+
+	externalNetwork := &types.ExternalNetwork{
+		Name:        d.Get("name").(string),
+		Description: d.Get("description").(string),
+		Configuration: &types.NetworkConfiguration{
+			FenceMode: "isolated",
+			IPScopes: &types.IPScopes{
+				IPScope: expandIPScopes(d.Get("ip_scope").([]interface{})),
+			},
+			RetainNetInfoAcrossDeployments: d.Get("retain_net_info_across_deployments").(bool),
+		},
+		VimPortGroupRefs: portGroups,
+	}
+
+	log.Printf("[INFO] EXTERNAL NETWORK: %#v", externalNetwork)
+
+	err = retryCallContext(ctx, func() *resource.RetryError {
+		task, err := govcd.CreateExternalNetwork(vcdClient.VCDClient, externalNetwork)
+		if err != nil {
+			return retryOnTransientError(err)
+		}
+		return retryOnTransientError(task.WaitTaskCompletionContext(ctx))
+	})
+	if err != nil {
+		return fmt.Errorf("Error creating external network %s: %#v", externalNetwork.Name, err)
+	}
+
+	d.SetId(externalNetwork.Name)
+
+	return resourceVcdExternalNetworkRead(d, meta)
+}
+
+func resourceVcdExternalNetworkRead(d *schema.ResourceData, meta interface{}) error {
+	vcdClient := meta.(*VCDClient)
+
+	externalNetwork, err := govcd.GetExternalNetworkByName(vcdClient.VCDClient, d.Id())
+	if err != nil {
+		return fmt.Errorf("Could not get external network: %s with error %v", d.Id(), err)
+	}
+	if externalNetwork == (govcd.ExternalNetwork{}) {
+		log.Printf("[DEBUG] External network no longer exists. Removing from tfstate")
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("name", externalNetwork.ExternalNetwork.Name)
+	d.Set("description", externalNetwork.ExternalNetwork.Description)
+	d.Set("href", externalNetwork.ExternalNetwork.HREF)
+	if c := externalNetwork.ExternalNetwork.Configuration; c != nil {
+		d.Set("retain_net_info_across_deployments", c.RetainNetInfoAcrossDeployments)
+		if c.IPScopes != nil {
+			d.Set("ip_scope", flattenIPScopes(c.IPScopes.IPScope))
+		}
+	}
+	if refs := externalNetwork.ExternalNetwork.VimPortGroupRefs; refs != nil {
+		vsphereNetworks, err := flattenVimPortGroupRefs(vcdClient, refs.VimObjectRef)
+		if err != nil {
+			return err
+		}
+		d.Set("vsphere_network", vsphereNetworks)
+	}
+
+	return nil
+}
+
+func resourceVcdExternalNetworkDelete(d *schema.ResourceData, meta interface{}) error {
+	vcdClient := meta.(*VCDClient)
+	ctx, cancel := operationContext(d, schema.TimeoutDelete)
+	defer cancel()
+
+	externalNetwork, err := govcd.GetExternalNetworkByName(vcdClient.VCDClient, d.Id())
+	if err != nil {
+		return fmt.Errorf("Could not get external network: %s with error %v", d.Id(), err)
+	}
+	if externalNetwork == (govcd.ExternalNetwork{}) {
+		return nil
+	}
+
+	err = retryCallContext(ctx, func() *resource.RetryError {
+		task, err := externalNetwork.Delete()
+		if err != nil {
+			return retryOnTransientError(fmt.Errorf("Error deleting external network: %w", err))
+		}
+		return retryOnTransientError(task.WaitTaskCompletionContext(ctx))
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// expandVimPortGroupRefs resolves each configured vSphere network to the
+// port group that backs it.
+func expandVimPortGroupRefs(vcdClient *VCDClient, configured []interface{}) (*types.VimObjectRefs, error) {
+	refs := make([]*types.VimObjectRef, 0, len(configured))
+
+	for _, raw := range configured {
+		data := raw.(map[string]interface{})
+		name := data["name"].(string)
+		vcenter := data["vcenter"].(string)
+
+		portGroup, err := vcdClient.FindPortGroup(name, vcenter)
+		if err != nil {
+			return nil, fmt.Errorf("Could not get port group: %s with error %v", name, err)
+		}
+		if portGroup == nil {
+			return nil, fmt.Errorf("Could not find port group: %s", name)
+		}
+
+		refs = append(refs, &types.VimObjectRef{
+			VimServerRef: &types.Reference{
+				HREF: portGroup.Vc,
+			},
+			MoRef:         portGroup.MoRef,
+			VimObjectType: portGroup.PortgroupType,
+		})
+	}
+
+	return &types.VimObjectRefs{VimObjectRef: refs}, nil
+}
+
+// flattenVimPortGroupRefs looks up the name and vCenter Server of each port
+// group backing an external network.
+func flattenVimPortGroupRefs(vcdClient *VCDClient, refs []*types.VimObjectRef) ([]map[string]interface{}, error) {
+	vsphereNetworks := make([]map[string]interface{}, 0, len(refs))
+
+	for _, ref := range refs {
+		vcenterHREF := ""
+		if ref.VimServerRef != nil {
+			vcenterHREF = ref.VimServerRef.HREF
+		}
+
+		portGroup, err := vcdClient.FindPortGroupByMoRef(ref.MoRef, vcenterHREF)
+		if err != nil {
+			return nil, fmt.Errorf("Could not get port group: %s with error %v", ref.MoRef, err)
+		}
+		if portGroup == nil {
+			return nil, fmt.Errorf("Could not find port group: %s", ref.MoRef)
+		}
+
+		vsphereNetworks = append(vsphereNetworks, map[string]interface{}{
+			"name":    portGroup.Name,
+			"vcenter": portGroup.VcName,
+			"type":    ref.VimObjectType,
+		})
+	}
+
+	return vsphereNetworks, nil
+}
@@ -0,0 +1,123 @@
+package vcd
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	govcd "github.com/vmware/go-vcloud-director/govcd"
+)
+
+func TestAccVcdExternalNetwork_Basic(t *testing.T) {
+	if v := os.Getenv("VCD_EXTERNAL_NETWORK_PORTGROUP"); v == "" {
+		t.Skip("Environment variable VCD_EXTERNAL_NETWORK_PORTGROUP must be set to run external network tests")
+		return
+	}
+	var externalNetwork govcd.ExternalNetwork
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckVcdExternalNetworkDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckVcdExternalNetwork_basic, os.Getenv("VCD_EXTERNAL_NETWORK_PORTGROUP"), os.Getenv("VCD_EXTERNAL_NETWORK_VCENTER")),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVcdExternalNetworkExists("vcd_external_network.foonet", &externalNetwork),
+					testAccCheckVcdExternalNetworkAttributes(&externalNetwork),
+					resource.TestCheckResourceAttr(
+						"vcd_external_network.foonet", "name", "foonet"),
+					resource.TestCheckResourceAttr(
+						"vcd_external_network.foonet", "ip_scope.0.gateway", "192.168.30.49"),
+					resource.TestCheckResourceAttr(
+						"vcd_external_network.foonet", "ip_scope.0.static_ip_pool.#", "1"),
+					resource.TestCheckResourceAttr(
+						"vcd_external_network.foonet", "vsphere_network.0.name", os.Getenv("VCD_EXTERNAL_NETWORK_PORTGROUP")),
+					resource.TestCheckResourceAttrSet(
+						"vcd_external_network.foonet", "vsphere_network.0.type"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckVcdExternalNetworkExists(n string, externalNetwork *govcd.ExternalNetwork) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No external network ID is set")
+		}
+
+		conn := testAccProvider.Meta().(*VCDClient)
+
+		resp, err := govcd.GetExternalNetworkByName(conn.VCDClient, rs.Primary.ID)
+		if err != nil {
+			return fmt.Errorf("error could not find external network: %v", err)
+		}
+		if resp == (govcd.ExternalNetwork{}) {
+			return fmt.Errorf("External network %s does not exist", rs.Primary.ID)
+		}
+
+		*externalNetwork = resp
+
+		return nil
+	}
+}
+
+func testAccCheckVcdExternalNetworkDestroy(s *terraform.State) error {
+	conn := testAccProvider.Meta().(*VCDClient)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "vcd_external_network" {
+			continue
+		}
+
+		externalNetwork, err := govcd.GetExternalNetworkByName(conn.VCDClient, rs.Primary.ID)
+		if err != nil || externalNetwork != (govcd.ExternalNetwork{}) {
+			return fmt.Errorf("External network %s still exists", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckVcdExternalNetworkAttributes(externalNetwork *govcd.ExternalNetwork) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if externalNetwork.ExternalNetwork.VimPortGroupRefs == nil ||
+			len(externalNetwork.ExternalNetwork.VimPortGroupRefs.VimObjectRef) != 1 {
+			return fmt.Errorf("Bad port groups: %#v", externalNetwork.ExternalNetwork.VimPortGroupRefs)
+		}
+
+		return nil
+	}
+}
+
+const testAccCheckVcdExternalNetwork_basic = `
+resource "vcd_external_network" "foonet" {
+  name        = "foonet"
+  description = "Terraform acceptance test"
+
+  ip_scope {
+    gateway = "192.168.30.49"
+    netmask = "255.255.255.240"
+    dns1    = "192.168.0.164"
+    dns2    = "192.168.0.196"
+
+    static_ip_pool {
+      start_address = "192.168.30.51"
+      end_address   = "192.168.30.62"
+    }
+  }
+
+  vsphere_network {
+    name    = "%s"
+    vcenter = "%s"
+  }
+}
+`
@@ -4,8 +4,8 @@ import (
 	"fmt"
 	"log"
 	"strings"
+	"time"
 
-	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/helper/schema"
 	govcd "github.com/vmware/go-vcloud-director/govcd"
 	types "github.com/vmware/go-vcloud-director/types/v56"
@@ -17,6 +17,11 @@ func resourceVcdFirewallRules() *schema.Resource {
 		Delete: resourceFirewallRulesDelete,
 		Read:   resourceFirewallRulesRead,
 
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+
 		Schema: map[string]*schema.Schema{
 			"edge_gateway": &schema.Schema{
 				Type:     schema.TypeString,
@@ -31,12 +36,14 @@ func resourceVcdFirewallRules() *schema.Resource {
 			},
 			"org": {
 				Type:     schema.TypeString,
-				Required: true,
+				Optional: true,
+				Computed: true,
 				ForceNew: true,
 			},
 			"vdc": {
 				Type:     schema.TypeString,
-				Required: true,
+				Optional: true,
+				Computed: true,
 				ForceNew: true,
 			},
 			"rule": &schema.Schema{
@@ -94,41 +101,31 @@ func resourceVcdFirewallRules() *schema.Resource {
 
 func resourceVcdFirewallRulesCreate(d *schema.ResourceData, meta interface{}) error {
 	vcdClient := meta.(*VCDClient)
+	ctx, cancel := operationContext(d, schema.TimeoutCreate)
+	defer cancel()
 
-	org, err := govcd.GetOrgByName(vcdClient.VCDClient, d.Get("org").(string))
+	_, vdc, err := vcdClient.getOrgAndVdc(d)
 	if err != nil {
-		return fmt.Errorf("Could not get Org: %s with error %v", d.Get("org").(string), err)
-	}
-	if org == (govcd.Org{}) {
-		return fmt.Errorf("Could not find Org: %s", d.Get("org").(string))
-	}
-	vdc, err := org.GetVdcByName(d.Get("vdc").(string))
-	if err != nil || vdc == (govcd.Vdc{}) {
-		return fmt.Errorf("Could not get vdc: %s with error %v", d.Get("vdc").(string), err)
+		return err
 	}
-	if vdc == (govcd.Vdc{}) {
-		return fmt.Errorf("Could not find vdc: %s", d.Get("vdc").(string))
-	}
-
-	vcdClient.Mutex.Lock()
-	defer vcdClient.Mutex.Unlock()
 
-	edgeGateway, err := vdc.FindEdgeGateway(d.Get("edge_gateway").(string))
+	edgeGateway, err := vcdClient.getEdgeGateway(vdc, d.Get("edge_gateway").(string))
 	if err != nil {
 		return fmt.Errorf("Unable to find edge gateway: %s, %s", d.Get("edge_gateway").(string), err)
 	}
 
-	err = retryCall(vcdClient.MaxRetryTimeout, func() *resource.RetryError {
-		edgeGateway.Refresh()
-		firewallRules, _ := expandFirewallRules(d, edgeGateway.EdgeGateway)
-		task, err := edgeGateway.CreateFirewallRules(d.Get("default_action").(string), firewallRules)
+	err = vcdClient.configureEdgeGateway(ctx, edgeGateway, func(edgeGateway *govcd.EdgeGateway, config *types.EdgeGatewayServiceConfiguration) error {
+		firewallRules, err := expandFirewallRules(d, firewallServiceToConfigure(edgeGateway, config))
 		if err != nil {
-			log.Printf("[INFO] Error setting firewall rules: %s", err)
-			return resource.RetryableError(
-				fmt.Errorf("Error setting firewall rules: %#v", err))
+			return fmt.Errorf("Error setting firewall rules: %w", err)
 		}
-
-		return resource.RetryableError(task.WaitTaskCompletion())
+		config.FirewallService = &types.FirewallService{
+			IsEnabled:        true,
+			DefaultAction:    d.Get("default_action").(string),
+			LogDefaultAction: true,
+			FirewallRule:     firewallRules,
+		}
+		return nil
 	})
 	if err != nil {
 		return fmt.Errorf("Error completing tasks: %#v", err)
@@ -141,37 +138,31 @@ func resourceVcdFirewallRulesCreate(d *schema.ResourceData, meta interface{}) er
 
 func resourceFirewallRulesDelete(d *schema.ResourceData, meta interface{}) error {
 	vcdClient := meta.(*VCDClient)
+	ctx, cancel := operationContext(d, schema.TimeoutDelete)
+	defer cancel()
 
-	org, err := govcd.GetOrgByName(vcdClient.VCDClient, d.Get("org").(string))
+	_, vdc, err := vcdClient.getOrgAndVdc(d)
 	if err != nil {
-		return fmt.Errorf("Could not get Org: %s with error %v", d.Get("org").(string), err)
-	}
-	if org == (govcd.Org{}) {
-		return fmt.Errorf("Could not find Org: %s", d.Get("org").(string))
-	}
-	vdc, err := org.GetVdcByName(d.Get("vdc").(string))
-	if err != nil || vdc == (govcd.Vdc{}) {
-		return fmt.Errorf("Could not get vdc: %s with error %v", d.Get("vdc").(string), err)
-	}
-	if vdc == (govcd.Vdc{}) {
-		return fmt.Errorf("Could not find vdc: %s", d.Get("vdc").(string))
+		return err
 	}
 
-	vcdClient.Mutex.Lock()
-	defer vcdClient.Mutex.Unlock()
-
-	edgeGateway, err := vdc.FindEdgeGateway(d.Get("edge_gateway").(string))
-
-	firewallRules := deleteFirewallRules(d, edgeGateway.EdgeGateway)
-	defaultAction := edgeGateway.EdgeGateway.Configuration.EdgeGatewayServiceConfiguration.FirewallService.DefaultAction
-	task, err := edgeGateway.CreateFirewallRules(defaultAction, firewallRules)
+	edgeGateway, err := vcdClient.getEdgeGateway(vdc, d.Get("edge_gateway").(string))
 	if err != nil {
-		return fmt.Errorf("Error deleting firewall rules: %#v", err)
+		return fmt.Errorf("Unable to find edge gateway: %s, %s", d.Get("edge_gateway").(string), err)
 	}
 
-	err = task.WaitTaskCompletion()
+	err = vcdClient.configureEdgeGateway(ctx, edgeGateway, func(edgeGateway *govcd.EdgeGateway, config *types.EdgeGatewayServiceConfiguration) error {
+		firewallService := firewallServiceToConfigure(edgeGateway, config)
+		config.FirewallService = &types.FirewallService{
+			IsEnabled:        true,
+			DefaultAction:    firewallService.DefaultAction,
+			LogDefaultAction: true,
+			FirewallRule:     deleteFirewallRules(d, firewallService),
+		}
+		return nil
+	})
 	if err != nil {
-		return fmt.Errorf("Error completing tasks: %#v", err)
+		return fmt.Errorf("Error deleting firewall rules: %#v", err)
 	}
 
 	return nil
@@ -180,22 +171,12 @@ func resourceFirewallRulesDelete(d *schema.ResourceData, meta interface{}) error
 func resourceFirewallRulesRead(d *schema.ResourceData, meta interface{}) error {
 	vcdClient := meta.(*VCDClient)
 
-	org, err := govcd.GetOrgByName(vcdClient.VCDClient, d.Get("org").(string))
+	_, vdc, err := vcdClient.getOrgAndVdc(d)
 	if err != nil {
-		return fmt.Errorf("Could not get Org: %s with error %v", d.Get("org").(string), err)
-	}
-	if org == (govcd.Org{}) {
-		return fmt.Errorf("Could not find Org: %s", d.Get("org").(string))
-	}
-	vdc, err := org.GetVdcByName(d.Get("vdc").(string))
-	if err != nil || vdc == (govcd.Vdc{}) {
-		return fmt.Errorf("Could not get vdc: %s with error %v", d.Get("vdc").(string), err)
-	}
-	if vdc == (govcd.Vdc{}) {
-		return fmt.Errorf("Could not find vdc: %s", d.Get("vdc").(string))
+		return err
 	}
 
-	edgeGateway, err := vdc.FindEdgeGateway(d.Get("edge_gateway").(string))
+	edgeGateway, err := vcdClient.getEdgeGateway(vdc, d.Get("edge_gateway").(string))
 	if err != nil {
 		return fmt.Errorf("Error finding edge gateway: %#v", err)
 	}
@@ -217,11 +198,22 @@ func resourceFirewallRulesRead(d *schema.ResourceData, meta interface{}) error {
 	d.Set("rule", ruleList)
 	d.Set("default_action", firewallRules.DefaultAction)
 
+	vcdClient.setOrgAndVdcNames(d)
+
 	return nil
 }
 
-func deleteFirewallRules(d *schema.ResourceData, gateway *types.EdgeGateway) []*types.FirewallRule {
-	firewallRules := gateway.Configuration.EdgeGatewayServiceConfiguration.FirewallService.FirewallRule
+// Returns the firewall service of config to change, starting from the
+// current one of the edge gateway.
+func firewallServiceToConfigure(edgeGateway *govcd.EdgeGateway, config *types.EdgeGatewayServiceConfiguration) *types.FirewallService {
+	if config.FirewallService != nil {
+		return config.FirewallService
+	}
+	return edgeGateway.EdgeGateway.Configuration.EdgeGatewayServiceConfiguration.FirewallService
+}
+
+func deleteFirewallRules(d *schema.ResourceData, firewallService *types.FirewallService) []*types.FirewallRule {
+	firewallRules := firewallService.FirewallRule
 	rulesCount := d.Get("rule.#").(int)
 	fwrules := make([]*types.FirewallRule, 0, len(firewallRules)-rulesCount)
 
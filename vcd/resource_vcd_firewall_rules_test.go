@@ -9,9 +9,38 @@ import (
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
 	govcd "github.com/vmware/go-vcloud-director/govcd"
+	types "github.com/vmware/go-vcloud-director/types/v56"
 )
 
+func init() {
+	resource.AddTestSweepers("vcd_firewall_rules", &resource.Sweeper{
+		Name: "vcd_firewall_rules",
+		F:    testSweepVcdFirewallRules,
+	})
+}
+
+// Removes the firewall rules whose description has the test prefix.
+func testSweepVcdFirewallRules(org string) error {
+	return sweepEdgeGateway(org, "firewall rules", func(edgeGateway *govcd.EdgeGateway, config *types.EdgeGatewayServiceConfiguration) int {
+		firewallService := firewallServiceToConfigure(edgeGateway, config)
+		if firewallService == nil {
+			return 0
+		}
+		swept := *firewallService
+		swept.FirewallRule = nil
+		for _, rule := range firewallService.FirewallRule {
+			if !isTestAccName(rule.Description) {
+				swept.FirewallRule = append(swept.FirewallRule, rule)
+			}
+		}
+		config.FirewallService = &swept
+		return len(firewallService.FirewallRule) - len(swept.FirewallRule)
+	})
+}
+
 func TestAccVcdFirewallRules_basic(t *testing.T) {
+	// The existing rules are read before the test steps
+	testAccCassette(t)
 
 	var existingRules, fwRules govcd.EdgeGateway
 	newConfig := createFirewallRulesConfigs(&existingRules)
@@ -84,6 +113,7 @@ func createFirewallRulesConfigs(existingRules *govcd.EdgeGateway) string {
 		Org:             os.Getenv("VCD_ORG"),
 		Href:            os.Getenv("VCD_URL"),
 		MaxRetryTimeout: 240,
+		InsecureFlag:    os.Getenv("VCD_ALLOW_UNVERIFIED_SSL") == "true",
 	}
 	conn, err := config.Client()
 	if err != nil {
@@ -112,7 +142,7 @@ resource "vcd_firewall_rules" "bar" {
 	default_action = "%s"
 
 	rule {
-		description = "Test rule"
+		description = "tf-acc-rule"
 		policy = "allow"
 		protocol = "any"
 		destination_port = "any"
@@ -2,6 +2,7 @@ package vcd
 
 import (
 	"log"
+	"time"
 
 	"bytes"
 	"fmt"
@@ -10,7 +11,6 @@ import (
 	"github.com/hashicorp/terraform/helper/hashcode"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/helper/schema"
-	govcd "github.com/vmware/go-vcloud-director/govcd"
 	types "github.com/vmware/go-vcloud-director/types/v56"
 )
 
@@ -20,6 +20,11 @@ func resourceVcdNetwork() *schema.Resource {
 		Read:   resourceVcdNetworkRead,
 		Delete: resourceVcdNetworkDelete,
 
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+
 		Schema: map[string]*schema.Schema{
 			"name": &schema.Schema{
 				Type:     schema.TypeString,
@@ -28,12 +33,14 @@ func resourceVcdNetwork() *schema.Resource {
 			},
 			"org": {
 				Type:     schema.TypeString,
-				Required: true,
+				Optional: true,
+				Computed: true,
 				ForceNew: true,
 			},
 			"vdc": {
 				Type:     schema.TypeString,
-				Required: true,
+				Optional: true,
+				Computed: true,
 				ForceNew: true,
 			},
 			"fence_mode": &schema.Schema{
@@ -152,26 +159,29 @@ func resourceVcdNetwork() *schema.Resource {
 
 func resourceVcdNetworkCreate(d *schema.ResourceData, meta interface{}) error {
 	vcdClient := meta.(*VCDClient)
-	log.Printf("[TRACE] CLIENT: %#v", vcdClient)
-	vcdClient.Mutex.Lock()
-	defer vcdClient.Mutex.Unlock()
+	ctx, cancel := operationContext(d, schema.TimeoutCreate)
+	defer cancel()
 
-	org, err := govcd.GetOrgByName(vcdClient.VCDClient, d.Get("org").(string))
+	_, vdc, err := vcdClient.getOrgAndVdc(d)
 	if err != nil {
-		return fmt.Errorf("Could not get Org: %s with error %v", d.Get("org").(string), err)
-	}
-	if org == (govcd.Org{}) {
-		return fmt.Errorf("Could not find Org: %s", d.Get("org").(string))
-	}
-	vdc, err := org.GetVdcByName(d.Get("vdc").(string))
-	if err != nil || vdc == (govcd.Vdc{}) {
-		return fmt.Errorf("Could not get vdc: %s with error %v", d.Get("vdc").(string), err)
+		return err
 	}
-	if vdc == (govcd.Vdc{}) {
-		return fmt.Errorf("Could not find vdc: %s", d.Get("vdc").(string))
+
+	edgeGateway, err := vcdClient.getEdgeGateway(vdc, d.Get("edge_gateway").(string))
+	if err != nil {
+		return fmt.Errorf("Unable to find edge gateway: %#v", err)
 	}
 
-	edgeGateway, err := vdc.FindEdgeGateway(d.Get("edge_gateway").(string))
+	// The network is attached to the edge gateway, which rejects changes
+	// while it is busy, so only networks on the same gateway wait for
+	// each other
+	vcdClient.lockEdgeGateway(edgeGateway.EdgeGateway.HREF)
+	defer vcdClient.unlockEdgeGateway(edgeGateway.EdgeGateway.HREF)
+
+	err = edgeGateway.Refresh()
+	if err != nil {
+		return fmt.Errorf("Error refreshing edge gateway: %#v", err)
+	}
 
 	ipRanges := expandIPRange(d.Get("static_ip_pool").(*schema.Set).List())
 
@@ -181,14 +191,16 @@ func resourceVcdNetworkCreate(d *schema.ResourceData, meta interface{}) error {
 		Configuration: &types.NetworkConfiguration{
 			FenceMode: d.Get("fence_mode").(string),
 			IPScopes: &types.IPScopes{
-				IPScope: types.IPScope{
-					IsInherited: false,
-					Gateway:     d.Get("gateway").(string),
-					Netmask:     d.Get("netmask").(string),
-					DNS1:        d.Get("dns1").(string),
-					DNS2:        d.Get("dns2").(string),
-					DNSSuffix:   d.Get("dns_suffix").(string),
-					IPRanges:    &ipRanges,
+				IPScope: []*types.IPScope{
+					&types.IPScope{
+						IsInherited: false,
+						Gateway:     d.Get("gateway").(string),
+						Netmask:     d.Get("netmask").(string),
+						DNS1:        d.Get("dns1").(string),
+						DNS2:        d.Get("dns2").(string),
+						DNSSuffix:   d.Get("dns_suffix").(string),
+						IPRanges:    &ipRanges,
+					},
 				},
 			},
 			BackwardCompatibilityMode: true,
@@ -201,9 +213,11 @@ func resourceVcdNetworkCreate(d *schema.ResourceData, meta interface{}) error {
 
 	log.Printf("[INFO] NETWORK: %#v", newnetwork)
 
-	err = retryCall(vcdClient.MaxRetryTimeout, func() *resource.RetryError {
-		return resource.RetryableError(vdc.CreateOrgVDCNetwork(newnetwork))
+	err = retryCallContext(ctx, func() *resource.RetryError {
+		return retryOnTransientError(vdc.CreateOrgVDCNetwork(newnetwork))
 	})
+	vcdClient.invalidateVdc(vdc)
+	vcdClient.invalidateEdgeGateway(edgeGateway.EdgeGateway.HREF)
 	if err != nil {
 		return fmt.Errorf("Error: %#v", err)
 	}
@@ -219,13 +233,13 @@ func resourceVcdNetworkCreate(d *schema.ResourceData, meta interface{}) error {
 	}
 
 	if dhcp, ok := d.GetOk("dhcp_pool"); ok {
-		err = retryCall(vcdClient.MaxRetryTimeout, func() *resource.RetryError {
+		err = retryCallContext(ctx, func() *resource.RetryError {
 			task, err := edgeGateway.AddDhcpPool(network.OrgVDCNetwork, dhcp.(*schema.Set).List())
 			if err != nil {
-				return resource.RetryableError(fmt.Errorf("Error adding DHCP pool: %#v", err))
+				return retryOnTransientError(fmt.Errorf("Error adding DHCP pool: %w", err))
 			}
 
-			return resource.RetryableError(task.WaitTaskCompletion())
+			return retryOnTransientError(task.WaitTaskCompletionContext(ctx))
 		})
 		if err != nil {
 			return fmt.Errorf("Error completing tasks: %#v", err)
@@ -240,27 +254,9 @@ func resourceVcdNetworkCreate(d *schema.ResourceData, meta interface{}) error {
 
 func resourceVcdNetworkRead(d *schema.ResourceData, meta interface{}) error {
 	vcdClient := meta.(*VCDClient)
-	log.Printf("[DEBUG] VCD Client configuration: %#v", vcdClient)
-	//log.Printf("[DEBUG] VCD Client configuration: %#v", vcdClient.OrgVdc)
-
-	org, err := govcd.GetOrgByName(vcdClient.VCDClient, d.Get("org").(string))
+	_, vdc, err := vcdClient.getOrgAndVdc(d)
 	if err != nil {
-		return fmt.Errorf("Could not get Org: %s with error %v", d.Get("org").(string), err)
-	}
-	if org == (govcd.Org{}) {
-		return fmt.Errorf("Could not find Org: %s", d.Get("org").(string))
-	}
-	vdc, err := org.GetVdcByName(d.Get("vdc").(string))
-	if err != nil || vdc == (govcd.Vdc{}) {
-		return fmt.Errorf("Could not get vdc: %s with error %v", d.Get("vdc").(string), err)
-	}
-	if vdc == (govcd.Vdc{}) {
-		return fmt.Errorf("Could not find vdc: %s", d.Get("vdc").(string))
-	}
-
-	err = vdc.Refresh()
-	if err != nil {
-		return fmt.Errorf("Error refreshing vdc: %#v", err)
+		return err
 	}
 
 	network, err := vdc.FindVDCNetwork(d.Id())
@@ -274,35 +270,27 @@ func resourceVcdNetworkRead(d *schema.ResourceData, meta interface{}) error {
 	d.Set("href", network.OrgVDCNetwork.HREF)
 	if c := network.OrgVDCNetwork.Configuration; c != nil {
 		d.Set("fence_mode", c.FenceMode)
-		if c.IPScopes != nil {
-			d.Set("gateway", c.IPScopes.IPScope.Gateway)
-			d.Set("netmask", c.IPScopes.IPScope.Netmask)
-			d.Set("dns1", c.IPScopes.IPScope.DNS1)
-			d.Set("dns2", c.IPScopes.IPScope.DNS2)
+		if c.IPScopes != nil && len(c.IPScopes.IPScope) > 0 {
+			d.Set("gateway", c.IPScopes.IPScope[0].Gateway)
+			d.Set("netmask", c.IPScopes.IPScope[0].Netmask)
+			d.Set("dns1", c.IPScopes.IPScope[0].DNS1)
+			d.Set("dns2", c.IPScopes.IPScope[0].DNS2)
 		}
 	}
 
+	vcdClient.setOrgAndVdcNames(d)
+
 	return nil
 }
 
 func resourceVcdNetworkDelete(d *schema.ResourceData, meta interface{}) error {
 	vcdClient := meta.(*VCDClient)
-	vcdClient.Mutex.Lock()
-	defer vcdClient.Mutex.Unlock()
+	ctx, cancel := operationContext(d, schema.TimeoutDelete)
+	defer cancel()
 
-	org, err := govcd.GetOrgByName(vcdClient.VCDClient, d.Get("org").(string))
+	_, vdc, err := vcdClient.getOrgAndVdc(d)
 	if err != nil {
-		return fmt.Errorf("Could not get Org: %s with error %v", d.Get("org").(string), err)
-	}
-	if org == (govcd.Org{}) {
-		return fmt.Errorf("Could not find Org: %s", d.Get("org").(string))
-	}
-	vdc, err := org.GetVdcByName(d.Get("vdc").(string))
-	if err != nil || vdc == (govcd.Vdc{}) {
-		return fmt.Errorf("Could not get vdc: %s with error %v", d.Get("vdc").(string), err)
-	}
-	if vdc == (govcd.Vdc{}) {
-		return fmt.Errorf("Could not find vdc: %s", d.Get("vdc").(string))
+		return err
 	}
 
 	err = vdc.Refresh()
@@ -315,14 +303,23 @@ func resourceVcdNetworkDelete(d *schema.ResourceData, meta interface{}) error {
 		return fmt.Errorf("Error finding network: %#v", err)
 	}
 
-	err = retryCall(vcdClient.MaxRetryTimeout, func() *resource.RetryError {
+	if network.OrgVDCNetwork.EdgeGateway != nil {
+		vcdClient.lockEdgeGateway(network.OrgVDCNetwork.EdgeGateway.HREF)
+		defer vcdClient.unlockEdgeGateway(network.OrgVDCNetwork.EdgeGateway.HREF)
+	}
+
+	err = retryCallContext(ctx, func() *resource.RetryError {
 		task, err := network.Delete()
 		if err != nil {
-			return resource.RetryableError(
-				fmt.Errorf("Error Deleting Network: %#v", err))
+			return retryOnTransientError(
+				fmt.Errorf("Error Deleting Network: %w", err))
 		}
-		return resource.RetryableError(task.WaitTaskCompletion())
+		return retryOnTransientError(task.WaitTaskCompletionContext(ctx))
 	})
+	vcdClient.invalidateVdc(vdc)
+	if network.OrgVDCNetwork.EdgeGateway != nil {
+		vcdClient.invalidateEdgeGateway(network.OrgVDCNetwork.EdgeGateway.HREF)
+	}
 	if err != nil {
 		return err
 	}
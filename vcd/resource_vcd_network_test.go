@@ -1,7 +1,9 @@
 package vcd
 
 import (
+	"context"
 	"fmt"
+	"log"
 	"os"
 	"regexp"
 	"testing"
@@ -11,6 +13,49 @@ import (
 	govcd "github.com/vmware/go-vcloud-director/govcd"
 )
 
+func init() {
+	resource.AddTestSweepers("vcd_network", &resource.Sweeper{
+		Name: "vcd_network",
+		// The vApps and NAT rules of the tests use their network
+		Dependencies: []string{"vcd_vapp", "vcd_dnat", "vcd_snat"},
+		F:            testSweepVcdNetwork,
+	})
+}
+
+// Deletes the networks with the test prefix in the VDC of the tests.
+func testSweepVcdNetwork(org string) error {
+	_, vdc, err := sweeperVdc(org)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), sweepTimeout)
+	defer cancel()
+	for _, networks := range vdc.Vdc.AvailableNetworks {
+		for _, reference := range networks.Network {
+			if !isTestAccName(reference.Name) {
+				continue
+			}
+			log.Printf("[INFO] Sweeping network %s", reference.Name)
+			network, err := vdc.FindVDCNetwork(reference.Name)
+			if err != nil {
+				return fmt.Errorf("error finding network %s: %s", reference.Name, err)
+			}
+			err = retryCallContext(ctx, func() *resource.RetryError {
+				task, err := network.Delete()
+				if err != nil {
+					return retryOnTransientError(fmt.Errorf("error deleting network: %w", err))
+				}
+				return retryOnTransientError(task.WaitTaskCompletionContext(ctx))
+			})
+			if err != nil {
+				return fmt.Errorf("error sweeping network %s: %s", reference.Name, err)
+			}
+		}
+	}
+	return nil
+}
+
 func TestAccVcdNetwork_Basic(t *testing.T) {
 	var network govcd.OrgVDCNetwork
 	generatedHrefRegexp := regexp.MustCompile("^https://")
@@ -26,7 +71,7 @@ func TestAccVcdNetwork_Basic(t *testing.T) {
 					testAccCheckVcdNetworkExists("vcd_network.foonet", &network),
 					testAccCheckVcdNetworkAttributes(&network),
 					resource.TestCheckResourceAttr(
-						"vcd_network.foonet", "name", "foonet"),
+						"vcd_network.foonet", "name", "tf-acc-foonet"),
 					resource.TestCheckResourceAttr(
 						"vcd_network.foonet", "static_ip_pool.#", "1"),
 					resource.TestCheckResourceAttr(
@@ -39,6 +84,27 @@ func TestAccVcdNetwork_Basic(t *testing.T) {
 	})
 }
 
+// Resources that don't set org and vdc are created in the provider ones,
+// which are recorded so that changing the provider later doesn't move them.
+func TestAccVcdNetwork_ProviderDefaults(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckVcdNetworkDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckVcdNetwork_providerDefaults, os.Getenv("VCD_EDGE_GATEWAY")),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"vcd_network.defaultnet", "org", os.Getenv("VCD_ORG")),
+					resource.TestCheckResourceAttr(
+						"vcd_network.defaultnet", "vdc", os.Getenv("VCD_VDC")),
+				),
+			},
+		},
+	})
+}
+
 func testAccCheckVcdNetworkExists(n string, network *govcd.OrgVDCNetwork) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[n]
@@ -100,7 +166,7 @@ func testAccCheckVcdNetworkDestroy(s *terraform.State) error {
 func testAccCheckVcdNetworkAttributes(network *govcd.OrgVDCNetwork) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 
-		if network.OrgVDCNetwork.Name != "foonet" {
+		if network.OrgVDCNetwork.Name != "tf-acc-foonet" {
 			return fmt.Errorf("Bad name: %s", network.OrgVDCNetwork.Name)
 		}
 
@@ -110,7 +176,7 @@ func testAccCheckVcdNetworkAttributes(network *govcd.OrgVDCNetwork) resource.Tes
 
 const testAccCheckVcdNetwork_basic = `
 resource "vcd_network" "foonet" {
-	name = "foonet"
+	name = "tf-acc-foonet"
 	org  = "%s"
 	vdc  = "%s"
 	edge_gateway = "%s"
@@ -121,3 +187,15 @@ resource "vcd_network" "foonet" {
 	}
 }
 `
+
+const testAccCheckVcdNetwork_providerDefaults = `
+resource "vcd_network" "defaultnet" {
+	name = "tf-acc-defaultnet"
+	edge_gateway = "%s"
+	gateway = "10.10.103.1"
+	static_ip_pool {
+		start_address = "10.10.103.2"
+		end_address = "10.10.103.254"
+	}
+}
+`
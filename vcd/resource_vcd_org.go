@@ -13,6 +13,7 @@ import (
 	types "github.com/vmware/go-vcloud-director/types/v56"
 	"log"
 	"strings"
+	"time"
 )
 
 func resourceOrg() *schema.Resource {
@@ -22,6 +23,10 @@ func resourceOrg() *schema.Resource {
 		Update: resourceOrgUpdate,
 		Delete: resourceOrgDelete,
 
+		Timeouts: &schema.ResourceTimeout{
+			Delete: schema.DefaultTimeout(30 * time.Minute),
+		},
+
 		Schema: map[string]*schema.Schema{
 			"name": &schema.Schema{
 				Type:     schema.TypeString,
@@ -128,6 +133,8 @@ func resourceOrgDelete(d *schema.ResourceData, m interface{}) error {
 
 	//DELETING
 	vcdClient := m.(*VCDClient)
+	ctx, cancel := operationContext(d, schema.TimeoutDelete)
+	defer cancel()
 	force := d.Get("force").(bool)
 	recursive := d.Get("recursive").(bool)
 
@@ -142,7 +149,8 @@ func resourceOrgDelete(d *schema.ResourceData, m interface{}) error {
 	//deletes organization
 	log.Printf("Deleting Org with id %s", d.State().ID)
 
-	err = org.Delete(force, recursive)
+	err = org.DeleteContext(ctx, force, recursive)
+	vcdClient.invalidateOrg(d.Get("name").(string))
 	if err != nil {
 		log.Printf("Error Deleting Org with id %s and error : %#v", d.State().ID, err)
 		return err
@@ -179,6 +187,7 @@ func resourceOrgUpdate(d *schema.ResourceData, m interface{}) error {
 
 	log.Printf("org with id %s found", d.State().ID)
 	_, err = org.Update()
+	vcdClient.invalidateOrg(orgName)
 
 	if err != nil {
 		log.Printf("Error updating org with id %s : %#v", d.State().ID, err)
@@ -0,0 +1,177 @@
+package vcd
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	govcd "github.com/vmware/go-vcloud-director/govcd"
+	types "github.com/vmware/go-vcloud-director/types/v56"
+)
+
+func resourceVcdOrgGroup() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceVcdOrgGroupCreate,
+		Read:   resourceVcdOrgGroupRead,
+		Update: resourceVcdOrgGroupUpdate,
+		Delete: resourceVcdOrgGroupDelete,
+
+		Schema: map[string]*schema.Schema{
+			"org": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+
+			"name": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the group in LDAP",
+			},
+
+			"role": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"description": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"href": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceVcdOrgGroupCreate(d *schema.ResourceData, meta interface{}) error {
+	vcdClient := meta.(*VCDClient)
+
+	adminOrg, err := getAdminOrg(vcdClient, vcdClient.orgName(d))
+	if err != nil {
+		return err
+	}
+	if !adminOrg.HasLdap() {
+		return fmt.Errorf("Org %s is not connected to LDAP, groups cannot be imported", vcdClient.orgName(d))
+	}
+
+	role, err := getOrgUserRole(&adminOrg, d.Get("role").(string))
+	if err != nil {
+		return err
+	}
+
+	group := &types.Group{
+		Name:        d.Get("name").(string),
+		Description: d.Get("description").(string),
+		Role:        role,
+	}
+
+	log.Printf("[INFO] Importing group %s into org %s", group.Name, vcdClient.orgName(d))
+	orgGroup, err := adminOrg.ImportGroup(group)
+	if err != nil {
+		return fmt.Errorf("Error importing group %s: %#v", group.Name, err)
+	}
+
+	d.SetId(orgGroup.Group.ID)
+
+	return resourceVcdOrgGroupRead(d, meta)
+}
+
+func resourceVcdOrgGroupRead(d *schema.ResourceData, meta interface{}) error {
+	vcdClient := meta.(*VCDClient)
+
+	adminOrg, err := getAdminOrg(vcdClient, vcdClient.orgName(d))
+	if err != nil {
+		return err
+	}
+	switch {
+	case !adminOrg.HasLdap():
+		log.Printf("[WARN] Org %s is no longer connected to LDAP, group %s cannot be verified", vcdClient.orgName(d), d.Get("name").(string))
+	// LDAP can only be searched through the cloud API of vCD 9.0 and later
+	case vcdClient.Client.APIVersionAtLeast(govcd.APIVersion90):
+		found, err := adminOrg.LdapHasGroup(d.Get("name").(string))
+		if err != nil {
+			return fmt.Errorf("Could not search LDAP for group: %s with error %v", d.Get("name").(string), err)
+		}
+		if !found {
+			log.Printf("[DEBUG] Group no longer exists in LDAP. Removing from tfstate")
+			d.SetId("")
+			return nil
+		}
+	}
+
+	orgGroup, err := adminOrg.GetGroupByName(d.Get("name").(string))
+	if err != nil {
+		return fmt.Errorf("Could not get group: %s with error %v", d.Get("name").(string), err)
+	}
+	if orgGroup == (govcd.OrgGroup{}) {
+		log.Printf("[DEBUG] Group no longer exists. Removing from tfstate")
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("name", orgGroup.Group.Name)
+	d.Set("description", orgGroup.Group.Description)
+	d.Set("href", orgGroup.Group.HREF)
+	if orgGroup.Group.Role != nil {
+		d.Set("role", orgGroup.Group.Role.Name)
+	}
+
+	vcdClient.setOrgName(d)
+
+	return nil
+}
+
+func resourceVcdOrgGroupUpdate(d *schema.ResourceData, meta interface{}) error {
+	vcdClient := meta.(*VCDClient)
+
+	adminOrg, err := getAdminOrg(vcdClient, vcdClient.orgName(d))
+	if err != nil {
+		return err
+	}
+
+	orgGroup, err := adminOrg.GetGroupByName(d.Get("name").(string))
+	if err != nil || orgGroup == (govcd.OrgGroup{}) {
+		return fmt.Errorf("Could not find group: %s with error %v", d.Get("name").(string), err)
+	}
+
+	if d.HasChange("role") {
+		role, err := getOrgUserRole(&adminOrg, d.Get("role").(string))
+		if err != nil {
+			return err
+		}
+		orgGroup.Group.Role = role
+	}
+	orgGroup.Group.Description = d.Get("description").(string)
+
+	err = orgGroup.Update()
+	if err != nil {
+		return fmt.Errorf("Error updating group %s: %#v", d.Get("name").(string), err)
+	}
+
+	return resourceVcdOrgGroupRead(d, meta)
+}
+
+func resourceVcdOrgGroupDelete(d *schema.ResourceData, meta interface{}) error {
+	vcdClient := meta.(*VCDClient)
+
+	adminOrg, err := getAdminOrg(vcdClient, vcdClient.orgName(d))
+	if err != nil {
+		return err
+	}
+
+	orgGroup, err := adminOrg.GetGroupByName(d.Get("name").(string))
+	if err != nil {
+		return fmt.Errorf("Could not get group: %s with error %v", d.Get("name").(string), err)
+	}
+	if orgGroup == (govcd.OrgGroup{}) {
+		return nil
+	}
+
+	return orgGroup.Delete()
+}
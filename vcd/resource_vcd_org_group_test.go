@@ -0,0 +1,97 @@
+package vcd
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	govcd "github.com/vmware/go-vcloud-director/govcd"
+)
+
+func TestAccVcdOrgGroup_Basic(t *testing.T) {
+	ldapGroup := os.Getenv("VCD_LDAP_GROUP")
+	if ldapGroup == "" || os.Getenv("VCD_ORG_USER_ROLE") == "" {
+		t.Skip("Environment variables VCD_LDAP_GROUP and VCD_ORG_USER_ROLE must be set to run org group tests")
+		return
+	}
+	var group govcd.OrgGroup
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckVcdOrgGroupDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckVcdOrgGroup_basic, testOrg, ldapGroup, os.Getenv("VCD_ORG_USER_ROLE")),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVcdOrgGroupExists("vcd_org_group.ldap", &group),
+					resource.TestCheckResourceAttr(
+						"vcd_org_group.ldap", "name", ldapGroup),
+					resource.TestCheckResourceAttr(
+						"vcd_org_group.ldap", "role", os.Getenv("VCD_ORG_USER_ROLE")),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckVcdOrgGroupExists(n string, group *govcd.OrgGroup) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No group ID is set")
+		}
+
+		conn := testAccProvider.Meta().(*VCDClient)
+
+		adminOrg, err := govcd.GetAdminOrgByName(conn.VCDClient, rs.Primary.Attributes["org"])
+		if err != nil {
+			return fmt.Errorf("Could not find test Org")
+		}
+
+		resp, err := adminOrg.GetGroupByName(rs.Primary.Attributes["name"])
+		if err != nil || resp == (govcd.OrgGroup{}) {
+			return fmt.Errorf("Group %s does not exist (%#v)", rs.Primary.Attributes["name"], err)
+		}
+
+		*group = resp
+
+		return nil
+	}
+}
+
+func testAccCheckVcdOrgGroupDestroy(s *terraform.State) error {
+	conn := testAccProvider.Meta().(*VCDClient)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "vcd_org_group" {
+			continue
+		}
+
+		adminOrg, err := govcd.GetAdminOrgByName(conn.VCDClient, rs.Primary.Attributes["org"])
+		if err != nil {
+			return fmt.Errorf("Could not find test Org")
+		}
+
+		group, err := adminOrg.GetGroupByName(rs.Primary.Attributes["name"])
+		if err != nil || group != (govcd.OrgGroup{}) {
+			return fmt.Errorf("Group %s still exists", rs.Primary.Attributes["name"])
+		}
+	}
+
+	return nil
+}
+
+const testAccCheckVcdOrgGroup_basic = `
+resource "vcd_org_group" "ldap" {
+  org  = "%s"
+  name = "%s"
+  role = "%s"
+}
+`
@@ -0,0 +1,197 @@
+package vcd
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	govcd "github.com/vmware/go-vcloud-director/govcd"
+	types "github.com/vmware/go-vcloud-director/types/v56"
+)
+
+func resourceVcdOrgRole() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceVcdOrgRoleCreate,
+		Read:   resourceVcdOrgRoleRead,
+		Update: resourceVcdOrgRoleUpdate,
+		Delete: resourceVcdOrgRoleDelete,
+
+		Schema: map[string]*schema.Schema{
+			"org": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"description": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"rights": &schema.Schema{
+				Type:     schema.TypeSet,
+				Required: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+			},
+
+			"href": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceVcdOrgRoleCreate(d *schema.ResourceData, meta interface{}) error {
+	vcdClient := meta.(*VCDClient)
+
+	// Before vCD 8.20 roles are global and can only be created in System
+	if !strings.EqualFold(vcdClient.orgName(d), "System") {
+		if err := vcdClient.Client.CheckAPIVersion(govcd.APIVersion820, "Roles in tenant orgs"); err != nil {
+			return err
+		}
+	}
+
+	adminOrg, err := getAdminOrg(vcdClient, vcdClient.orgName(d))
+	if err != nil {
+		return err
+	}
+
+	rights, err := expandRightReferences(&adminOrg, d.Get("rights").(*schema.Set).List())
+	if err != nil {
+		return err
+	}
+
+	role := &types.Role{
+		Name:            d.Get("name").(string),
+		Description:     d.Get("description").(string),
+		RightReferences: rights,
+	}
+
+	log.Printf("[INFO] Creating role %s in org %s", role.Name, vcdClient.orgName(d))
+	created, err := adminOrg.CreateRole(role)
+	if err != nil {
+		return fmt.Errorf("Error creating role %s: %#v", role.Name, err)
+	}
+
+	d.SetId(created.Role.ID)
+
+	return resourceVcdOrgRoleRead(d, meta)
+}
+
+func resourceVcdOrgRoleRead(d *schema.ResourceData, meta interface{}) error {
+	vcdClient := meta.(*VCDClient)
+
+	adminOrg, err := getAdminOrg(vcdClient, vcdClient.orgName(d))
+	if err != nil {
+		return err
+	}
+
+	role, err := adminOrg.GetRoleByName(d.Get("name").(string))
+	if err != nil {
+		return fmt.Errorf("Could not get role: %s with error %v", d.Get("name").(string), err)
+	}
+	if role == (govcd.Role{}) {
+		log.Printf("[DEBUG] Role no longer exists. Removing from tfstate")
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("name", role.Role.Name)
+	d.Set("description", role.Role.Description)
+	d.Set("href", role.Role.HREF)
+
+	rights := make([]interface{}, 0)
+	if role.Role.RightReferences != nil {
+		for _, right := range role.Role.RightReferences.RightReference {
+			rights = append(rights, right.Name)
+		}
+	}
+	d.Set("rights", schema.NewSet(schema.HashString, rights))
+
+	vcdClient.setOrgName(d)
+
+	return nil
+}
+
+func resourceVcdOrgRoleUpdate(d *schema.ResourceData, meta interface{}) error {
+	vcdClient := meta.(*VCDClient)
+
+	adminOrg, err := getAdminOrg(vcdClient, vcdClient.orgName(d))
+	if err != nil {
+		return err
+	}
+
+	role, err := adminOrg.GetRoleByName(d.Get("name").(string))
+	if err != nil || role == (govcd.Role{}) {
+		return fmt.Errorf("Could not find role: %s with error %v", d.Get("name").(string), err)
+	}
+
+	if d.HasChange("rights") {
+		rights, err := expandRightReferences(&adminOrg, d.Get("rights").(*schema.Set).List())
+		if err != nil {
+			return err
+		}
+		role.Role.RightReferences = rights
+	}
+	role.Role.Description = d.Get("description").(string)
+
+	err = role.Update()
+	if err != nil {
+		return fmt.Errorf("Error updating role %s: %#v", d.Get("name").(string), err)
+	}
+
+	return resourceVcdOrgRoleRead(d, meta)
+}
+
+func resourceVcdOrgRoleDelete(d *schema.ResourceData, meta interface{}) error {
+	vcdClient := meta.(*VCDClient)
+
+	adminOrg, err := getAdminOrg(vcdClient, vcdClient.orgName(d))
+	if err != nil {
+		return err
+	}
+
+	role, err := adminOrg.GetRoleByName(d.Get("name").(string))
+	if err != nil {
+		return fmt.Errorf("Could not get role: %s with error %v", d.Get("name").(string), err)
+	}
+	if role == (govcd.Role{}) {
+		return nil
+	}
+
+	return role.Delete()
+}
+
+// expandRightReferences resolves right names to references, failing on
+// the first name vCD does not know about.
+func expandRightReferences(adminOrg *govcd.AdminOrg, configured []interface{}) (*types.RightReferences, error) {
+	references := make([]*types.Reference, 0, len(configured))
+
+	for _, raw := range configured {
+		name := raw.(string)
+		right, err := adminOrg.GetRightReference(name)
+		if err != nil {
+			return nil, fmt.Errorf("Could not get right: %s with error %v", name, err)
+		}
+		if right == nil {
+			return nil, fmt.Errorf("Could not find right: %s", name)
+		}
+		references = append(references, &types.Reference{
+			HREF: right.HREF,
+			Name: right.Name,
+			Type: right.Type,
+		})
+	}
+
+	return &types.RightReferences{RightReference: references}, nil
+}
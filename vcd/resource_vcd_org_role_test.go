@@ -0,0 +1,117 @@
+package vcd
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	govcd "github.com/vmware/go-vcloud-director/govcd"
+)
+
+func TestAccVcdOrgRole_Basic(t *testing.T) {
+	testAccSkipOnMock(t, "the admin API")
+	var role govcd.Role
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckVcdOrgRoleDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckVcdOrgRole_basic, testOrg, testOrg),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVcdOrgRoleExists("vcd_org_role.operator", &role),
+					resource.TestCheckResourceAttr(
+						"vcd_org_role.operator", "name", "tf-operator"),
+					resource.TestCheckResourceAttr(
+						"vcd_org_role.operator", "rights.#", "1"),
+					resource.TestCheckResourceAttrSet(
+						"data.vcd_right.console", "href"),
+				),
+			},
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckVcdOrgRole_updated, testOrg),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVcdOrgRoleExists("vcd_org_role.operator", &role),
+					resource.TestCheckResourceAttr(
+						"vcd_org_role.operator", "rights.#", "2"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckVcdOrgRoleExists(n string, role *govcd.Role) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No role ID is set")
+		}
+
+		conn := testAccProvider.Meta().(*VCDClient)
+
+		adminOrg, err := govcd.GetAdminOrgByName(conn.VCDClient, rs.Primary.Attributes["org"])
+		if err != nil {
+			return fmt.Errorf("Could not find test Org")
+		}
+
+		resp, err := adminOrg.GetRoleByName(rs.Primary.Attributes["name"])
+		if err != nil || resp == (govcd.Role{}) {
+			return fmt.Errorf("Role %s does not exist (%#v)", rs.Primary.Attributes["name"], err)
+		}
+
+		*role = resp
+
+		return nil
+	}
+}
+
+func testAccCheckVcdOrgRoleDestroy(s *terraform.State) error {
+	conn := testAccProvider.Meta().(*VCDClient)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "vcd_org_role" {
+			continue
+		}
+
+		adminOrg, err := govcd.GetAdminOrgByName(conn.VCDClient, rs.Primary.Attributes["org"])
+		if err != nil {
+			return fmt.Errorf("Could not find test Org")
+		}
+
+		role, err := adminOrg.GetRoleByName(rs.Primary.Attributes["name"])
+		if err != nil || role != (govcd.Role{}) {
+			return fmt.Errorf("Role %s still exists", rs.Primary.Attributes["name"])
+		}
+	}
+
+	return nil
+}
+
+const testAccCheckVcdOrgRole_basic = `
+data "vcd_right" "console" {
+  org  = "%s"
+  name = "vApp: Use Console"
+}
+
+resource "vcd_org_role" "operator" {
+  org         = "%s"
+  name        = "tf-operator"
+  description = "Terraform acceptance test"
+  rights      = ["${data.vcd_right.console.name}"]
+}
+`
+
+const testAccCheckVcdOrgRole_updated = `
+resource "vcd_org_role" "operator" {
+  org         = "%s"
+  name        = "tf-operator"
+  description = "Terraform acceptance test"
+  rights      = ["vApp: Use Console", "vApp: Power Operations"]
+}
+`
@@ -10,7 +10,7 @@ import (
 )
 
 func TestAccVcdOrgBasic(t *testing.T) {
-
+	testAccSkipOnMock(t, "the admin API")
 	var e govcd.Org
 
 	resource.Test(t, resource.TestCase{
@@ -0,0 +1,254 @@
+package vcd
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	govcd "github.com/vmware/go-vcloud-director/govcd"
+	types "github.com/vmware/go-vcloud-director/types/v56"
+)
+
+func resourceVcdOrgUser() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceVcdOrgUserCreate,
+		Read:   resourceVcdOrgUserRead,
+		Update: resourceVcdOrgUserUpdate,
+		Delete: resourceVcdOrgUserDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceVcdOrgUserImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"org": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"password": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				Description: "The user's password. vCD never returns it, so changes made outside Terraform are not detected",
+			},
+
+			"full_name": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"email": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"role": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"enabled": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+
+			"locked": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "vCD locks users after too many failed logins. Setting this to false unlocks them, it cannot be set to true",
+			},
+
+			"deployed_vm_quota": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+				Default:  0,
+			},
+
+			"stored_vm_quota": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+				Default:  0,
+			},
+
+			"href": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceVcdOrgUserCreate(d *schema.ResourceData, meta interface{}) error {
+	vcdClient := meta.(*VCDClient)
+
+	adminOrg, err := getAdminOrg(vcdClient, vcdClient.orgName(d))
+	if err != nil {
+		return err
+	}
+
+	role, err := getOrgUserRole(&adminOrg, d.Get("role").(string))
+	if err != nil {
+		return err
+	}
+
+	user := &types.User{
+		Name:     d.Get("name").(string),
+		Password: d.Get("password").(string),
+		Role:     role,
+	}
+	setOrgUserData(d, user)
+
+	log.Printf("[INFO] Creating user %s in org %s", user.Name, vcdClient.orgName(d))
+	orgUser, err := adminOrg.CreateUser(user)
+	if err != nil {
+		return fmt.Errorf("Error creating user %s: %#v", user.Name, err)
+	}
+
+	d.SetId(orgUser.User.ID)
+
+	return resourceVcdOrgUserRead(d, meta)
+}
+
+func resourceVcdOrgUserRead(d *schema.ResourceData, meta interface{}) error {
+	vcdClient := meta.(*VCDClient)
+
+	adminOrg, err := getAdminOrg(vcdClient, vcdClient.orgName(d))
+	if err != nil {
+		return err
+	}
+
+	orgUser, err := adminOrg.GetUserByName(d.Get("name").(string))
+	if err != nil {
+		return fmt.Errorf("Could not get user: %s with error %v", d.Get("name").(string), err)
+	}
+	if orgUser == (govcd.OrgUser{}) {
+		log.Printf("[DEBUG] User no longer exists. Removing from tfstate")
+		d.SetId("")
+		return nil
+	}
+
+	user := orgUser.User
+	d.SetId(user.ID)
+	d.Set("name", user.Name)
+	d.Set("href", user.HREF)
+	d.Set("full_name", user.FullName)
+	d.Set("email", user.EmailAddress)
+	d.Set("enabled", user.IsEnabled)
+	d.Set("locked", user.IsLocked)
+	d.Set("deployed_vm_quota", user.DeployedVmQuota)
+	d.Set("stored_vm_quota", user.StoredVmQuota)
+	if user.Role != nil {
+		d.Set("role", user.Role.Name)
+	}
+
+	vcdClient.setOrgName(d)
+
+	return nil
+}
+
+func resourceVcdOrgUserUpdate(d *schema.ResourceData, meta interface{}) error {
+	vcdClient := meta.(*VCDClient)
+
+	adminOrg, err := getAdminOrg(vcdClient, vcdClient.orgName(d))
+	if err != nil {
+		return err
+	}
+
+	orgUser, err := adminOrg.GetUserByName(d.Get("name").(string))
+	if err != nil || orgUser == (govcd.OrgUser{}) {
+		return fmt.Errorf("Could not find user: %s with error %v", d.Get("name").(string), err)
+	}
+
+	if d.HasChange("role") {
+		role, err := getOrgUserRole(&adminOrg, d.Get("role").(string))
+		if err != nil {
+			return err
+		}
+		orgUser.User.Role = role
+	}
+	if d.HasChange("password") {
+		orgUser.User.Password = d.Get("password").(string)
+	}
+	setOrgUserData(d, orgUser.User)
+
+	err = orgUser.Update()
+	if err != nil {
+		return fmt.Errorf("Error updating user %s: %#v", d.Get("name").(string), err)
+	}
+
+	return resourceVcdOrgUserRead(d, meta)
+}
+
+func resourceVcdOrgUserDelete(d *schema.ResourceData, meta interface{}) error {
+	vcdClient := meta.(*VCDClient)
+
+	adminOrg, err := getAdminOrg(vcdClient, vcdClient.orgName(d))
+	if err != nil {
+		return err
+	}
+
+	orgUser, err := adminOrg.GetUserByName(d.Get("name").(string))
+	if err != nil {
+		return fmt.Errorf("Could not get user: %s with error %v", d.Get("name").(string), err)
+	}
+	if orgUser == (govcd.OrgUser{}) {
+		return nil
+	}
+
+	return orgUser.Delete()
+}
+
+// resourceVcdOrgUserImport imports a user given an ID of the form org/user
+func resourceVcdOrgUserImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.SplitN(d.Id(), "/", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return nil, fmt.Errorf("Invalid import ID %q, expected org/user", d.Id())
+	}
+
+	d.Set("org", parts[0])
+	d.Set("name", parts[1])
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func getAdminOrg(vcdClient *VCDClient, orgName string) (govcd.AdminOrg, error) {
+	adminOrg, err := govcd.GetAdminOrgByName(vcdClient.VCDClient, orgName)
+	if err != nil {
+		return govcd.AdminOrg{}, fmt.Errorf("Could not get Org: %s with error %v", orgName, err)
+	}
+	if adminOrg == (govcd.AdminOrg{}) {
+		return govcd.AdminOrg{}, fmt.Errorf("Could not find Org: %s", orgName)
+	}
+	return adminOrg, nil
+}
+
+func getOrgUserRole(adminOrg *govcd.AdminOrg, roleName string) (*types.Reference, error) {
+	role, err := adminOrg.GetRoleReference(roleName)
+	if err != nil {
+		return nil, fmt.Errorf("Could not get role: %s with error %v", roleName, err)
+	}
+	if role == nil {
+		return nil, fmt.Errorf("Could not find role: %s", roleName)
+	}
+	return role, nil
+}
+
+func setOrgUserData(d *schema.ResourceData, user *types.User) {
+	user.FullName = d.Get("full_name").(string)
+	user.EmailAddress = d.Get("email").(string)
+	user.IsEnabled = d.Get("enabled").(bool)
+	user.IsLocked = d.Get("locked").(bool)
+	user.DeployedVmQuota = d.Get("deployed_vm_quota").(int)
+	user.StoredVmQuota = d.Get("stored_vm_quota").(int)
+}
@@ -0,0 +1,118 @@
+package vcd
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	govcd "github.com/vmware/go-vcloud-director/govcd"
+)
+
+func TestAccVcdOrgUser_Basic(t *testing.T) {
+	if v := os.Getenv("VCD_ORG_USER_ROLE"); v == "" {
+		t.Skip("Environment variable VCD_ORG_USER_ROLE must be set to run org user tests")
+		return
+	}
+	var user govcd.OrgUser
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckVcdOrgUserDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckVcdOrgUser_basic, testOrg, os.Getenv("VCD_ORG_USER_ROLE"), "Terraform User"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVcdOrgUserExists("vcd_org_user.tfuser", &user),
+					resource.TestCheckResourceAttr(
+						"vcd_org_user.tfuser", "name", "tfuser"),
+					resource.TestCheckResourceAttr(
+						"vcd_org_user.tfuser", "full_name", "Terraform User"),
+					resource.TestCheckResourceAttr(
+						"vcd_org_user.tfuser", "deployed_vm_quota", "10"),
+				),
+			},
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckVcdOrgUser_basic, testOrg, os.Getenv("VCD_ORG_USER_ROLE"), "Terraform User Updated"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVcdOrgUserExists("vcd_org_user.tfuser", &user),
+					resource.TestCheckResourceAttr(
+						"vcd_org_user.tfuser", "full_name", "Terraform User Updated"),
+				),
+			},
+			resource.TestStep{
+				ResourceName:            "vcd_org_user.tfuser",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateId:           testOrg + "/tfuser",
+				ImportStateVerifyIgnore: []string{"password"},
+			},
+		},
+	})
+}
+
+func testAccCheckVcdOrgUserExists(n string, user *govcd.OrgUser) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No user ID is set")
+		}
+
+		conn := testAccProvider.Meta().(*VCDClient)
+
+		adminOrg, err := govcd.GetAdminOrgByName(conn.VCDClient, rs.Primary.Attributes["org"])
+		if err != nil {
+			return fmt.Errorf("Could not find test Org")
+		}
+
+		resp, err := adminOrg.GetUserByName(rs.Primary.Attributes["name"])
+		if err != nil || resp == (govcd.OrgUser{}) {
+			return fmt.Errorf("User %s does not exist (%#v)", rs.Primary.Attributes["name"], err)
+		}
+
+		*user = resp
+
+		return nil
+	}
+}
+
+func testAccCheckVcdOrgUserDestroy(s *terraform.State) error {
+	conn := testAccProvider.Meta().(*VCDClient)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "vcd_org_user" {
+			continue
+		}
+
+		adminOrg, err := govcd.GetAdminOrgByName(conn.VCDClient, rs.Primary.Attributes["org"])
+		if err != nil {
+			return fmt.Errorf("Could not find test Org")
+		}
+
+		user, err := adminOrg.GetUserByName(rs.Primary.Attributes["name"])
+		if err != nil || user != (govcd.OrgUser{}) {
+			return fmt.Errorf("User %s still exists", rs.Primary.Attributes["name"])
+		}
+	}
+
+	return nil
+}
+
+const testAccCheckVcdOrgUser_basic = `
+resource "vcd_org_user" "tfuser" {
+  org               = "%s"
+  name              = "tfuser"
+  password          = "Terraform-Test-1"
+  role              = "%s"
+  full_name         = "%s"
+  email             = "tfuser@example.com"
+  deployed_vm_quota = 10
+  stored_vm_quota   = 20
+}
+`
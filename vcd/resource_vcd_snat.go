@@ -2,10 +2,11 @@ package vcd
 
 import (
 	"fmt"
+	"time"
 
-	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/helper/schema"
 	govcd "github.com/vmware/go-vcloud-director/govcd"
+	types "github.com/vmware/go-vcloud-director/types/v56"
 )
 
 func resourceVcdSNAT() *schema.Resource {
@@ -14,15 +15,22 @@ func resourceVcdSNAT() *schema.Resource {
 		Delete: resourceVcdSNATDelete,
 		Read:   resourceVcdSNATRead,
 
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+
 		Schema: map[string]*schema.Schema{
 			"org": {
 				Type:     schema.TypeString,
-				Required: true,
+				Optional: true,
+				Computed: true,
 				ForceNew: true,
 			},
 			"vdc": {
 				Type:     schema.TypeString,
-				Required: true,
+				Optional: true,
+				Computed: true,
 				ForceNew: true,
 			},
 			"edge_gateway": &schema.Schema{
@@ -42,77 +50,61 @@ func resourceVcdSNAT() *schema.Resource {
 				Required: true,
 				ForceNew: true,
 			},
+
+			"description": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
 		},
 	}
 }
 
 func resourceVcdSNATCreate(d *schema.ResourceData, meta interface{}) error {
 	vcdClient := meta.(*VCDClient)
-	org, err := govcd.GetOrgByName(vcdClient.VCDClient, d.Get("org").(string))
+	ctx, cancel := operationContext(d, schema.TimeoutCreate)
+	defer cancel()
+
+	_, vdc, err := vcdClient.getOrgAndVdc(d)
 	if err != nil {
-		return fmt.Errorf("Could not get Org: %s with error %v", d.Get("org").(string), err)
-	}
-	if org == (govcd.Org{}) {
-		return fmt.Errorf("Could not find Org: %s", d.Get("org").(string))
-	}
-	vdc, err := org.GetVdcByName(d.Get("vdc").(string))
-	if err != nil || vdc == (govcd.Vdc{}) {
-		return fmt.Errorf("Could not get vdc: %s with error %v", d.Get("vdc").(string), err)
-	}
-	if vdc == (govcd.Vdc{}) {
-		return fmt.Errorf("Could not find vdc: %s", d.Get("vdc").(string))
+		return err
 	}
-	// Multiple VCD components need to run operations on the Edge Gateway, as
-	// the edge gatway will throw back an error if it is already performing an
-	// operation we must wait until we can aquire a lock on the client
-	vcdClient.Mutex.Lock()
-	defer vcdClient.Mutex.Unlock()
 
-	// Creating a loop to offer further protection from the edge gateway erroring
-	// due to being busy eg another person is using another client so wouldn't be
-	// constrained by out lock. If the edge gateway reurns with a busy error, wait
-	// 3 seconds and then try again. Continue until a non-busy error or success
-
-	edgeGateway, err := vdc.FindEdgeGateway(d.Get("edge_gateway").(string))
+	edgeGateway, err := vcdClient.getEdgeGateway(vdc, d.Get("edge_gateway").(string))
 	if err != nil {
 		return fmt.Errorf("Unable to find edge gateway: %#v", err)
 	}
 
-	err = retryCall(vcdClient.MaxRetryTimeout, func() *resource.RetryError {
-		task, err := edgeGateway.AddNATMapping("SNAT", d.Get("internal_ip").(string),
+	// Multiple VCD components need to run operations on the Edge Gateway, as
+	// the edge gatway will throw back an error if it is already performing an
+	// operation the rule is queued and submitted together with the other
+	// changes to the gateway
+	err = vcdClient.configureEdgeGateway(ctx, edgeGateway, func(edgeGateway *govcd.EdgeGateway, config *types.EdgeGatewayServiceConfiguration) error {
+		edgeGateway.AddNATPortMappingToConfig(config, "SNAT",
+			d.Get("internal_ip").(string),
+			"any",
 			d.Get("external_ip").(string),
-			"any")
-		if err != nil {
-			return resource.RetryableError(fmt.Errorf("Error setting SNAT rules: %#v", err))
-		}
-		return resource.RetryableError(task.WaitTaskCompletion())
+			"any",
+			d.Get("description").(string))
+		return nil
 	})
 	if err != nil {
 		return err
 	}
 
 	d.SetId(d.Get("internal_ip").(string))
+	vcdClient.setOrgAndVdcNames(d)
 	return nil
 }
 
 func resourceVcdSNATRead(d *schema.ResourceData, meta interface{}) error {
 	vcdClient := meta.(*VCDClient)
-	org, err := govcd.GetOrgByName(vcdClient.VCDClient, d.Get("org").(string))
+	_, vdc, err := vcdClient.getOrgAndVdc(d)
 	if err != nil {
-		return fmt.Errorf("Could not get Org: %s with error %v", d.Get("org").(string), err)
-	}
-	if org == (govcd.Org{}) {
-		return fmt.Errorf("Could not find Org: %s", d.Get("org").(string))
-	}
-	vdc, err := org.GetVdcByName(d.Get("vdc").(string))
-	if err != nil || vdc == (govcd.Vdc{}) {
-		return fmt.Errorf("Could not get vdc: %s with error %v", d.Get("vdc").(string), err)
-	}
-	if vdc == (govcd.Vdc{}) {
-		return fmt.Errorf("Could not find vdc: %s", d.Get("vdc").(string))
+		return err
 	}
 
-	e, err := vdc.FindEdgeGateway(d.Get("edge_gateway").(string))
+	e, err := vcdClient.getEdgeGateway(vdc, d.Get("edge_gateway").(string))
 
 	if err != nil {
 		return fmt.Errorf("Unable to find edge gateway: %#v", err)
@@ -125,6 +117,7 @@ func resourceVcdSNATRead(d *schema.ResourceData, meta interface{}) error {
 			r.GatewayNatRule.OriginalIP == d.Id() {
 			found = true
 			d.Set("external_ip", r.GatewayNatRule.TranslatedIP)
+			d.Set("description", r.Description)
 		}
 	}
 
@@ -132,44 +125,31 @@ func resourceVcdSNATRead(d *schema.ResourceData, meta interface{}) error {
 		d.SetId("")
 	}
 
+	vcdClient.setOrgAndVdcNames(d)
+
 	return nil
 }
 
 func resourceVcdSNATDelete(d *schema.ResourceData, meta interface{}) error {
 	vcdClient := meta.(*VCDClient)
-	org, err := govcd.GetOrgByName(vcdClient.VCDClient, d.Get("org").(string))
+	ctx, cancel := operationContext(d, schema.TimeoutDelete)
+	defer cancel()
+
+	_, vdc, err := vcdClient.getOrgAndVdc(d)
 	if err != nil {
-		return fmt.Errorf("Could not get Org: %s with error %v", d.Get("org").(string), err)
-	}
-	if org == (govcd.Org{}) {
-		return fmt.Errorf("Could not find Org: %s", d.Get("org").(string))
-	}
-	vdc, err := org.GetVdcByName(d.Get("vdc").(string))
-	if err != nil || vdc == (govcd.Vdc{}) {
-		return fmt.Errorf("Could not get vdc: %s with error %v", d.Get("vdc").(string), err)
-	}
-	if vdc == (govcd.Vdc{}) {
-		return fmt.Errorf("Could not find vdc: %s", d.Get("vdc").(string))
+		return err
 	}
-	// Multiple VCD components need to run operations on the Edge Gateway, as
-	// the edge gatway will throw back an error if it is already performing an
-	// operation we must wait until we can aquire a lock on the client
-	vcdClient.Mutex.Lock()
-	defer vcdClient.Mutex.Unlock()
 
-	edgeGateway, err := vdc.FindEdgeGateway(d.Get("edge_gateway").(string))
+	edgeGateway, err := vcdClient.getEdgeGateway(vdc, d.Get("edge_gateway").(string))
 	if err != nil {
 		return fmt.Errorf("Unable to find edge gateway: %#v", err)
 	}
 
-	err = retryCall(vcdClient.MaxRetryTimeout, func() *resource.RetryError {
-		task, err := edgeGateway.RemoveNATMapping("SNAT", d.Get("internal_ip").(string),
-			d.Get("external_ip").(string),
+	err = vcdClient.configureEdgeGateway(ctx, edgeGateway, func(edgeGateway *govcd.EdgeGateway, config *types.EdgeGatewayServiceConfiguration) error {
+		edgeGateway.RemoveNATPortMappingFromConfig(config, "SNAT",
+			d.Get("internal_ip").(string),
 			"")
-		if err != nil {
-			return resource.RetryableError(fmt.Errorf("Error setting SNAT rules: %#v", err))
-		}
-		return resource.RetryableError(task.WaitTaskCompletion())
+		return nil
 	})
 	if err != nil {
 		return err
@@ -8,8 +8,24 @@ import (
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
 	govcd "github.com/vmware/go-vcloud-director/govcd"
+	types "github.com/vmware/go-vcloud-director/types/v56"
 )
 
+func init() {
+	resource.AddTestSweepers("vcd_snat", &resource.Sweeper{
+		Name: "vcd_snat",
+		F:    testSweepVcdSNAT,
+	})
+}
+
+func testSweepVcdSNAT(org string) error {
+	return sweepEdgeGateway(org, "SNAT rules", func(edgeGateway *govcd.EdgeGateway, config *types.EdgeGatewayServiceConfiguration) int {
+		natService, removed := sweepNatRules(natServiceToSweep(edgeGateway, config), "SNAT")
+		config.NatService = natService
+		return removed
+	})
+}
+
 func TestAccVcdSNAT_Basic(t *testing.T) {
 	if v := os.Getenv("VCD_EXTERNAL_IP"); v == "" {
 		t.Skip("Environment variable VCD_EXTERNAL_IP must be set to run SNAT tests")
@@ -31,6 +47,8 @@ func TestAccVcdSNAT_Basic(t *testing.T) {
 						"vcd_snat.bar", "external_ip", os.Getenv("VCD_EXTERNAL_IP")),
 					resource.TestCheckResourceAttr(
 						"vcd_snat.bar", "internal_ip", "10.10.102.0/24"),
+					resource.TestCheckResourceAttr(
+						"vcd_snat.bar", "description", "tf-acc-snat"),
 				),
 			},
 		},
@@ -133,5 +151,6 @@ resource "vcd_snat" "bar" {
 	edge_gateway = "%s"
 	external_ip = "%s"
 	internal_ip = "10.10.102.0/24"
+	description = "tf-acc-snat"
 }
 `
@@ -1,8 +1,10 @@
 package vcd
 
 import (
+	"context"
 	"fmt"
 	"log"
+	"time"
 
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/helper/schema"
@@ -17,6 +19,12 @@ func resourceVcdVApp() *schema.Resource {
 		Read:   resourceVcdVAppRead,
 		Delete: resourceVcdVAppDelete,
 
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+			Update: schema.DefaultTimeout(30 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:     schema.TypeString,
@@ -25,12 +33,14 @@ func resourceVcdVApp() *schema.Resource {
 			},
 			"org": {
 				Type:     schema.TypeString,
-				Required: true,
+				Optional: true,
+				Computed: true,
 				ForceNew: true,
 			},
 			"vdc": {
 				Type:     schema.TypeString,
-				Required: true,
+				Optional: true,
+				Computed: true,
 				ForceNew: true,
 			},
 			"template_name": {
@@ -42,23 +52,34 @@ func resourceVcdVApp() *schema.Resource {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
+			"template_vm_name": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				Description:   "The VM of the template to deploy. Defaults to its first VM",
+				ConflictsWith: []string{"instantiate"},
+			},
 			"network_name": {
-				Type:     schema.TypeString,
-				Optional: true,
-				ForceNew: true,
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"instantiate"},
 			},
 			"memory": {
-				Type:     schema.TypeInt,
-				Optional: true,
+				Type:          schema.TypeInt,
+				Optional:      true,
+				ConflictsWith: []string{"instantiate"},
 			},
 			"cpus": {
-				Type:     schema.TypeInt,
-				Optional: true,
+				Type:          schema.TypeInt,
+				Optional:      true,
+				ConflictsWith: []string{"instantiate"},
 			},
 			"ip": {
-				Type:     schema.TypeString,
-				Optional: true,
-				Computed: true,
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"instantiate"},
 			},
 			"storage_profile": {
 				Type:     schema.TypeString,
@@ -69,17 +90,19 @@ func resourceVcdVApp() *schema.Resource {
 				Optional: true,
 			},
 			"initscript": {
-				Type:     schema.TypeString,
-				Optional: true,
-				ForceNew: true,
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"instantiate"},
 			},
 			"metadata": {
 				Type:     schema.TypeMap,
 				Optional: true,
 			},
 			"ovf": {
-				Type:     schema.TypeMap,
-				Optional: true,
+				Type:          schema.TypeMap,
+				Optional:      true,
+				ConflictsWith: []string{"instantiate"},
 			},
 			"href": {
 				Type:     schema.TypeString,
@@ -96,31 +119,130 @@ func resourceVcdVApp() *schema.Resource {
 				Optional: true,
 				Default:  true,
 			},
+			"instantiate": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     false,
+				Description: "Instantiate the whole template, keeping its VMs and vApp networks, instead of composing the vApp from one of its VMs",
+			},
+			"vm": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Settings of a VM of the template to override when instantiating it",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"template_vm_name": {
+							Type:     schema.TypeString,
+							Required: true,
+							ForceNew: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Optional: true,
+							ForceNew: true,
+						},
+						"cpus": {
+							Type:     schema.TypeInt,
+							Optional: true,
+							ForceNew: true,
+						},
+						"memory": {
+							Type:     schema.TypeInt,
+							Optional: true,
+							ForceNew: true,
+						},
+						"networks": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							ForceNew:    true,
+							Description: "Networks of the NICs of the VM, in order",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"name": {
+										Type:        schema.TypeString,
+										Required:    true,
+										ForceNew:    true,
+										Description: "A vApp network of the template, or an org VDC network",
+									},
+									"ip": {
+										Type:        schema.TypeString,
+										Optional:    true,
+										ForceNew:    true,
+										Description: "An IP address, or one of allocated, dhcp and none. Defaults to allocated",
+									},
+								},
+							},
+						},
+						"computer_name": {
+							Type:     schema.TypeString,
+							Optional: true,
+							ForceNew: true,
+						},
+						"initscript": {
+							Type:     schema.TypeString,
+							Optional: true,
+							ForceNew: true,
+						},
+					},
+				},
+			},
+			"vms": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The VMs of an instantiated vApp",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"href": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"status": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"cpus": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"memory": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"ip": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
 		},
 	}
 }
 
 func resourceVcdVAppCreate(d *schema.ResourceData, meta interface{}) error {
 	vcdClient := meta.(*VCDClient)
-	org, err := govcd.GetOrgByName(vcdClient.VCDClient, d.Get("org").(string))
-	if err != nil {
-		return fmt.Errorf("Could not get Org: %s with error %v", d.Get("org").(string), err)
-	}
-	if org == (govcd.Org{}) {
-		return fmt.Errorf("Could not find Org: %s", d.Get("org").(string))
-	}
-	vdc, err := org.GetVdcByName(d.Get("vdc").(string))
-	if err != nil || vdc == (govcd.Vdc{}) {
-		return fmt.Errorf("Could not get vdc: %s with error %v", d.Get("vdc").(string), err)
+	ctx, cancel := operationContext(d, schema.TimeoutCreate)
+	defer cancel()
+
+	if _, ok := d.GetOk("vm"); ok && !d.Get("instantiate").(bool) {
+		return fmt.Errorf("vm blocks need instantiate")
 	}
-	if vdc == (govcd.Vdc{}) {
-		return fmt.Errorf("Could not find vdc: %s", d.Get("vdc").(string))
+
+	org, vdc, err := vcdClient.getOrgAndVdc(d)
+	if err != nil {
+		return err
 	}
 
 	if _, ok := d.GetOk("template_name"); ok {
 		if _, ok := d.GetOk("catalog_name"); ok {
 
-			catalog, err := org.FindCatalog(d.Get("catalog_name").(string))
+			catalog, err := vcdClient.getCatalog(org, d.Get("catalog_name").(string))
 			if err != nil || catalog == (govcd.Catalog{}) {
 				return fmt.Errorf("Error finding catalog: %#v", err)
 			}
@@ -135,6 +257,14 @@ func resourceVcdVAppCreate(d *schema.ResourceData, meta interface{}) error {
 				return fmt.Errorf("Error finding VAppTemplate: %#v", err)
 			}
 
+			if d.Get("instantiate").(bool) {
+				if err := instantiateVApp(ctx, d, vcdClient, vdc, vapptemplate); err != nil {
+					return err
+				}
+				d.SetId(d.Get("name").(string))
+				return resourceVcdVAppUpdate(d, meta)
+			}
+
 			log.Printf("[DEBUG] VAppTemplate: %#v", vapptemplate)
 			net, err := vdc.FindVDCNetwork(d.Get("network_name").(string))
 			if err != nil {
@@ -157,15 +287,16 @@ func resourceVcdVAppCreate(d *schema.ResourceData, meta interface{}) error {
 			vapp, err := vdc.FindVAppByName(d.Get("name").(string))
 
 			if err != nil {
-				err = retryCall(vcdClient.MaxRetryTimeout, func() *resource.RetryError {
-					task, err := vdc.ComposeVApp(nets, vapptemplate, storage_profile_reference, d.Get("name").(string), d.Get("description").(string), d.Get("accept_all_eulas").(bool))
+				err = retryCallContext(ctx, func() *resource.RetryError {
+					task, err := vdc.ComposeVApp(nets, vapptemplate, d.Get("template_vm_name").(string), storage_profile_reference, d.Get("name").(string), d.Get("description").(string), d.Get("accept_all_eulas").(bool))
 
 					if err != nil {
-						return resource.RetryableError(fmt.Errorf("Error creating vapp: %#v", err))
+						return retryOnTransientError(fmt.Errorf("Error creating vapp: %w", err))
 					}
 
-					return resource.RetryableError(task.WaitTaskCompletion())
+					return retryOnTransientError(task.WaitTaskCompletionContext(ctx))
 				})
+				vcdClient.invalidateVdc(vdc)
 
 				if err != nil {
 					return fmt.Errorf("Error creating vapp: %#v", err)
@@ -176,19 +307,19 @@ func resourceVcdVAppCreate(d *schema.ResourceData, meta interface{}) error {
 				}
 			}
 
-			err = retryCall(vcdClient.MaxRetryTimeout, func() *resource.RetryError {
+			err = retryCallContext(ctx, func() *resource.RetryError {
 				task, err := vapp.ChangeVMName(d.Get("name").(string))
 				if err != nil {
-					return resource.RetryableError(fmt.Errorf("Error with vm name change: %#v", err))
+					return retryOnTransientError(fmt.Errorf("Error with vm name change: %w", err))
 				}
 
-				return resource.RetryableError(task.WaitTaskCompletion())
+				return retryOnTransientError(task.WaitTaskCompletionContext(ctx))
 			})
 			if err != nil {
 				return fmt.Errorf("Error changing vmname: %#v", err)
 			}
 
-			err = retryCall(vcdClient.MaxRetryTimeout, func() *resource.RetryError {
+			err = retryCallContext(ctx, func() *resource.RetryError {
 				networks := []map[string]interface{}{map[string]interface{}{
 					"ip":         d.Get("ip").(string),
 					"is_primary": true,
@@ -196,22 +327,22 @@ func resourceVcdVAppCreate(d *schema.ResourceData, meta interface{}) error {
 				}}
 				task, err := vapp.ChangeNetworkConfig(networks, d.Get("ip").(string))
 				if err != nil {
-					return resource.RetryableError(fmt.Errorf("Error with Networking change: %#v", err))
+					return retryOnTransientError(fmt.Errorf("Error with Networking change: %w", err))
 				}
-				return resource.RetryableError(task.WaitTaskCompletion())
+				return retryOnTransientError(task.WaitTaskCompletionContext(ctx))
 			})
 			if err != nil {
 				return fmt.Errorf("Error changing network: %#v", err)
 			}
 
 			if ovf, ok := d.GetOk("ovf"); ok {
-				err := retryCall(vcdClient.MaxRetryTimeout, func() *resource.RetryError {
+				err := retryCallContext(ctx, func() *resource.RetryError {
 					task, err := vapp.SetOvf(convertToStringMap(ovf.(map[string]interface{})))
 
 					if err != nil {
-						return resource.RetryableError(fmt.Errorf("Error set ovf: %#v", err))
+						return retryOnTransientError(fmt.Errorf("Error set ovf: %w", err))
 					}
-					return resource.RetryableError(task.WaitTaskCompletion())
+					return retryOnTransientError(task.WaitTaskCompletionContext(ctx))
 				})
 				if err != nil {
 					return fmt.Errorf("Error completing tasks: %#v", err)
@@ -219,12 +350,12 @@ func resourceVcdVAppCreate(d *schema.ResourceData, meta interface{}) error {
 			}
 
 			if d.Get("power_on").(bool) == true {
-				err = retryCall(vcdClient.MaxRetryTimeout, func() *resource.RetryError {
+				err = retryCallContext(ctx, func() *resource.RetryError {
 					task, err := vapp.PowerOn()
 					if err != nil {
-						return resource.RetryableError(fmt.Errorf("Error powerOn machine: %#v", err))
+						return retryOnTransientError(fmt.Errorf("Error powerOn machine: %w", err))
 					}
-					return resource.RetryableError(task.WaitTaskCompletion())
+					return retryOnTransientError(task.WaitTaskCompletionContext(ctx))
 				})
 
 				if err != nil {
@@ -234,13 +365,13 @@ func resourceVcdVAppCreate(d *schema.ResourceData, meta interface{}) error {
 
 			initscript, ok := d.GetOk("initscript")
 			if ok {
-				err = retryCall(vcdClient.MaxRetryTimeout, func() *resource.RetryError {
+				err = retryCallContext(ctx, func() *resource.RetryError {
 					log.Printf("running customisation script")
 					task, err := vapp.RunCustomizationScript(d.Get("name").(string), initscript.(string))
 					if err != nil {
-						return resource.RetryableError(fmt.Errorf("Error with setting init script: %#v", err))
+						return retryOnTransientError(fmt.Errorf("Error with setting init script: %w", err))
 					}
-					return resource.RetryableError(task.WaitTaskCompletion())
+					return retryOnTransientError(task.WaitTaskCompletionContext(ctx))
 				})
 				if err != nil {
 					return fmt.Errorf("Error completing tasks: %#v", err)
@@ -249,19 +380,20 @@ func resourceVcdVAppCreate(d *schema.ResourceData, meta interface{}) error {
 
 		}
 	} else {
-		err := retryCall(vcdClient.MaxRetryTimeout, func() *resource.RetryError {
+		err := retryCallContext(ctx, func() *resource.RetryError {
 			e := vdc.ComposeRawVApp(d.Get("name").(string))
 
 			if e != nil {
-				return resource.RetryableError(fmt.Errorf("Error: %#v", e))
+				return retryOnTransientError(fmt.Errorf("Error: %w", e))
 			}
 
 			e = vdc.Refresh()
 			if e != nil {
-				return resource.RetryableError(fmt.Errorf("Error: %#v", e))
+				return retryOnTransientError(fmt.Errorf("Error: %w", e))
 			}
 			return nil
 		})
+		vcdClient.invalidateVdc(vdc)
 		if err != nil {
 			return err
 		}
@@ -272,21 +404,82 @@ func resourceVcdVAppCreate(d *schema.ResourceData, meta interface{}) error {
 	return resourceVcdVAppUpdate(d, meta)
 }
 
-func resourceVcdVAppUpdate(d *schema.ResourceData, meta interface{}) error {
-	vcdClient := meta.(*VCDClient)
-	org, err := govcd.GetOrgByName(vcdClient.VCDClient, d.Get("org").(string))
-	if err != nil {
-		return fmt.Errorf("Could not get Org: %s with error %v", d.Get("org").(string), err)
+// instantiateVApp creates the vApp of d from all of vapptemplate, with the
+// VM overrides of its vm blocks. The vApp is left powered off for
+// resourceVcdVAppUpdate to power on, as it does for new vApps.
+func instantiateVApp(ctx context.Context, d *schema.ResourceData, vcdClient *VCDClient, vdc govcd.Vdc, vapptemplate govcd.VAppTemplate) error {
+	templateNetworks := make(map[string]bool)
+	if networkConfig := vapptemplate.VAppTemplate.NetworkConfigSection; networkConfig != nil {
+		for _, network := range networkConfig.NetworkConfig {
+			templateNetworks[network.NetworkName] = true
+		}
+	}
+
+	var vms []govcd.VMInstantiation
+	var nets []*types.OrgVDCNetwork
+	for _, rawVM := range d.Get("vm").([]interface{}) {
+		vm := rawVM.(map[string]interface{})
+		instantiation := govcd.VMInstantiation{
+			TemplateVMName:      vm["template_vm_name"].(string),
+			Name:                vm["name"].(string),
+			CPUs:                vm["cpus"].(int),
+			Memory:              vm["memory"].(int),
+			ComputerName:        vm["computer_name"].(string),
+			CustomizationScript: vm["initscript"].(string),
+		}
+		for _, rawNetwork := range vm["networks"].([]interface{}) {
+			network := rawNetwork.(map[string]interface{})
+			name := network["name"].(string)
+			// Networks that aren't vApp networks of the template are org
+			// VDC networks the vApp is bridged to
+			if !templateNetworks[name] {
+				net, err := vdc.FindVDCNetwork(name)
+				if err != nil {
+					return fmt.Errorf("error finding network %s: %s", name, err)
+				}
+				nets = append(nets, net.OrgVDCNetwork)
+				templateNetworks[name] = true
+			}
+			instantiation.Networks = append(instantiation.Networks, govcd.VMNetworkConnection{
+				Network: name,
+				IP:      network["ip"].(string),
+			})
+		}
+		vms = append(vms, instantiation)
 	}
-	if org == (govcd.Org{}) {
-		return fmt.Errorf("Could not find Org: %s", d.Get("org").(string))
+
+	storageProfile := types.Reference{}
+	if name := d.Get("storage_profile").(string); name != "" {
+		var err error
+		storageProfile, err = vdc.FindStorageProfileReference(name)
+		if err != nil {
+			return fmt.Errorf("error finding storage profile %s: %s", name, err)
+		}
 	}
-	vdc, err := org.GetVdcByName(d.Get("vdc").(string))
-	if err != nil || vdc == (govcd.Vdc{}) {
-		return fmt.Errorf("Could not get vdc: %s with error %v", d.Get("vdc").(string), err)
+
+	err := retryCallContext(ctx, func() *resource.RetryError {
+		task, err := vdc.InstantiateVApp(vapptemplate, vms, nets, storageProfile, d.Get("name").(string), d.Get("description").(string), d.Get("accept_all_eulas").(bool))
+		if err != nil {
+			return retryOnTransientError(fmt.Errorf("error instantiating vapp: %w", err))
+		}
+		return retryOnTransientError(task.WaitTaskCompletionContext(ctx))
+	})
+	vcdClient.invalidateVdc(vdc)
+	if err != nil {
+		return fmt.Errorf("error instantiating vapp: %s", err)
 	}
-	if vdc == (govcd.Vdc{}) {
-		return fmt.Errorf("Could not find vdc: %s", d.Get("vdc").(string))
+
+	return nil
+}
+
+func resourceVcdVAppUpdate(d *schema.ResourceData, meta interface{}) error {
+	vcdClient := meta.(*VCDClient)
+	ctx, cancel := operationContext(d, schema.TimeoutUpdate)
+	defer cancel()
+
+	_, vdc, err := vcdClient.getOrgAndVdc(d)
+	if err != nil {
+		return err
 	}
 	vapp, err := vdc.FindVAppByName(d.Id())
 
@@ -307,7 +500,7 @@ func resourceVcdVAppUpdate(d *schema.ResourceData, meta interface{}) error {
 			if err != nil {
 				return fmt.Errorf("Error deleting metadata: %#v", err)
 			}
-			err = task.WaitTaskCompletion()
+			err = task.WaitTaskCompletionContext(ctx)
 			if err != nil {
 				return fmt.Errorf("Error completing tasks: %#v", err)
 			}
@@ -318,7 +511,7 @@ func resourceVcdVAppUpdate(d *schema.ResourceData, meta interface{}) error {
 			if err != nil {
 				return fmt.Errorf("Error adding metadata: %#v", err)
 			}
-			err = task.WaitTaskCompletion()
+			err = task.WaitTaskCompletionContext(ctx)
 			if err != nil {
 				return fmt.Errorf("Error completing tasks: %#v", err)
 			}
@@ -327,13 +520,13 @@ func resourceVcdVAppUpdate(d *schema.ResourceData, meta interface{}) error {
 	}
 
 	if d.HasChange("storage_profile") {
-		err = retryCall(vcdClient.MaxRetryTimeout, func() *resource.RetryError {
+		err = retryCallContext(ctx, func() *resource.RetryError {
 			task, err := vapp.ChangeStorageProfile(d.Get("storage_profile").(string))
 			if err != nil {
-				return resource.RetryableError(fmt.Errorf("Error changing storage_profile: %#v", err))
+				return retryOnTransientError(fmt.Errorf("Error changing storage_profile: %w", err))
 			}
 
-			return resource.RetryableError(task.WaitTaskCompletion())
+			return retryOnTransientError(task.WaitTaskCompletionContext(ctx))
 		})
 		if err != nil {
 			return err
@@ -353,7 +546,7 @@ func resourceVcdVAppUpdate(d *schema.ResourceData, meta interface{}) error {
 			}
 
 			if task.Task != nil {
-				err = task.WaitTaskCompletion()
+				err = task.WaitTaskCompletionContext(ctx)
 				if err != nil {
 					return fmt.Errorf("Error completing tasks: %#v", err)
 				}
@@ -361,13 +554,13 @@ func resourceVcdVAppUpdate(d *schema.ResourceData, meta interface{}) error {
 		}
 
 		if d.HasChange("memory") {
-			err = retryCall(vcdClient.MaxRetryTimeout, func() *resource.RetryError {
+			err = retryCallContext(ctx, func() *resource.RetryError {
 				task, err := vapp.ChangeMemorySize(d.Get("memory").(int))
 				if err != nil {
-					return resource.RetryableError(fmt.Errorf("Error changing memory size: %#v", err))
+					return retryOnTransientError(fmt.Errorf("Error changing memory size: %w", err))
 				}
 
-				return resource.RetryableError(task.WaitTaskCompletion())
+				return retryOnTransientError(task.WaitTaskCompletionContext(ctx))
 			})
 			if err != nil {
 				return err
@@ -375,13 +568,13 @@ func resourceVcdVAppUpdate(d *schema.ResourceData, meta interface{}) error {
 		}
 
 		if d.HasChange("cpus") {
-			err = retryCall(vcdClient.MaxRetryTimeout, func() *resource.RetryError {
+			err = retryCallContext(ctx, func() *resource.RetryError {
 				task, err := vapp.ChangeCPUcount(d.Get("cpus").(int))
 				if err != nil {
-					return resource.RetryableError(fmt.Errorf("Error changing cpu count: %#v", err))
+					return retryOnTransientError(fmt.Errorf("Error changing cpu count: %w", err))
 				}
 
-				return resource.RetryableError(task.WaitTaskCompletion())
+				return retryOnTransientError(task.WaitTaskCompletionContext(ctx))
 			})
 			if err != nil {
 				return fmt.Errorf("Error completing task: %#v", err)
@@ -393,20 +586,20 @@ func resourceVcdVAppUpdate(d *schema.ResourceData, meta interface{}) error {
 			if err != nil {
 				return fmt.Errorf("Error Powering Up: %#v", err)
 			}
-			err = task.WaitTaskCompletion()
+			err = task.WaitTaskCompletionContext(ctx)
 			if err != nil {
 				return fmt.Errorf("Error completing tasks: %#v", err)
 			}
 		}
 
 		if ovf, ok := d.GetOk("ovf"); ok {
-			err = retryCall(vcdClient.MaxRetryTimeout, func() *resource.RetryError {
+			err = retryCallContext(ctx, func() *resource.RetryError {
 				task, err := vapp.SetOvf(convertToStringMap(ovf.(map[string]interface{})))
 
 				if err != nil {
-					return resource.RetryableError(fmt.Errorf("Error set ovf: %#v", err))
+					return retryOnTransientError(fmt.Errorf("Error set ovf: %w", err))
 				}
-				return resource.RetryableError(task.WaitTaskCompletion())
+				return retryOnTransientError(task.WaitTaskCompletionContext(ctx))
 			})
 			if err != nil {
 				return fmt.Errorf("Error completing tasks: %#v", err)
@@ -420,33 +613,23 @@ func resourceVcdVAppUpdate(d *schema.ResourceData, meta interface{}) error {
 
 func resourceVcdVAppRead(d *schema.ResourceData, meta interface{}) error {
 	vcdClient := meta.(*VCDClient)
-	org, err := govcd.GetOrgByName(vcdClient.VCDClient, d.Get("org").(string))
-	if err != nil {
-		return fmt.Errorf("Could not get Org: %s with error %v", d.Get("org").(string), err)
-	}
-	if org == (govcd.Org{}) {
-		return fmt.Errorf("Could not find Org: %s", d.Get("org").(string))
-	}
-	vdc, err := org.GetVdcByName(d.Get("vdc").(string))
-	if err != nil || vdc == (govcd.Vdc{}) {
-		return fmt.Errorf("Could not get vdc: %s with error %v", d.Get("vdc").(string), err)
-	}
-	if vdc == (govcd.Vdc{}) {
-		return fmt.Errorf("Could not find vdc: %s", d.Get("vdc").(string))
-	}
-	err = vdc.Refresh()
+	org, vdc, err := vcdClient.getOrgAndVdc(d)
 	if err != nil {
-		return fmt.Errorf("Error refreshing vdc: %#v", err)
+		return err
 	}
 
-	_, err = vdc.FindVAppByName(d.Id())
+	vapp, err := vdc.FindVAppByName(d.Id())
 	if err != nil {
 		log.Printf("[DEBUG] Unable to find vapp. Removing from tfstate")
 		d.SetId("")
 		return nil
 	}
 
-	if _, ok := d.GetOk("ip"); ok {
+	if d.Get("instantiate").(bool) {
+		if err := d.Set("vms", flattenInstantiatedVMs(vapp)); err != nil {
+			return err
+		}
+	} else if _, ok := d.GetOk("ip"); ok {
 		ip := "allocated"
 
 		oldIp, newIp := d.GetChange("ip")
@@ -468,9 +651,32 @@ func resourceVcdVAppRead(d *schema.ResourceData, meta interface{}) error {
 		d.Set("ip", "allocated")
 	}
 
+	vcdClient.setOrgAndVdcNames(d)
+
 	return nil
 }
 
+// Returns the vms of an instantiated vApp.
+func flattenInstantiatedVMs(vapp govcd.VApp) []map[string]interface{} {
+	vms := make([]map[string]interface{}, 0)
+	if vapp.VApp.Children == nil {
+		return vms
+	}
+	for _, vm := range vapp.VApp.Children.VM {
+		hardware := flattenVirtualHardware(vm.VirtualHardwareSection)
+		_, ip := flattenNetworkConnections(vm.NetworkConnectionSection)
+		vms = append(vms, map[string]interface{}{
+			"name":   vm.Name,
+			"href":   vm.HREF,
+			"status": types.VAppStatuses[vm.Status],
+			"cpus":   hardware["cpus"],
+			"memory": hardware["memory"],
+			"ip":     ip,
+		})
+	}
+	return vms
+}
+
 func getVAppIPAddress(d *schema.ResourceData, meta interface{}, vdc govcd.Vdc, org govcd.Org) (string, error) {
 	vcdClient := meta.(*VCDClient)
 	var ip string
@@ -501,19 +707,12 @@ func getVAppIPAddress(d *schema.ResourceData, meta interface{}, vdc govcd.Vdc, o
 
 func resourceVcdVAppDelete(d *schema.ResourceData, meta interface{}) error {
 	vcdClient := meta.(*VCDClient)
-	org, err := govcd.GetOrgByName(vcdClient.VCDClient, d.Get("org").(string))
+	ctx, cancel := operationContext(d, schema.TimeoutDelete)
+	defer cancel()
+
+	_, vdc, err := vcdClient.getOrgAndVdc(d)
 	if err != nil {
-		return fmt.Errorf("Could not get Org: %s with error %v", d.Get("org").(string), err)
-	}
-	if org == (govcd.Org{}) {
-		return fmt.Errorf("Could not find Org: %s", d.Get("org").(string))
-	}
-	vdc, err := org.GetVdcByName(d.Get("vdc").(string))
-	if err != nil || vdc == (govcd.Vdc{}) {
-		return fmt.Errorf("Could not get vdc: %s with error %v", d.Get("vdc").(string), err)
-	}
-	if vdc == (govcd.Vdc{}) {
-		return fmt.Errorf("Could not find vdc: %s", d.Get("vdc").(string))
+		return err
 	}
 	vapp, err := vdc.FindVAppByName(d.Id())
 
@@ -525,23 +724,24 @@ func resourceVcdVAppDelete(d *schema.ResourceData, meta interface{}) error {
 		return fmt.Errorf("Error getting VApp status: %#v", err)
 	}
 
-	_ = retryCall(vcdClient.MaxRetryTimeout, func() *resource.RetryError {
+	_ = retryCallContext(ctx, func() *resource.RetryError {
 		task, err := vapp.Undeploy()
 		if err != nil {
-			return resource.RetryableError(fmt.Errorf("Error undeploying: %#v", err))
+			return retryOnTransientError(fmt.Errorf("Error undeploying: %w", err))
 		}
 
-		return resource.RetryableError(task.WaitTaskCompletion())
+		return retryOnTransientError(task.WaitTaskCompletionContext(ctx))
 	})
 
-	err = retryCall(vcdClient.MaxRetryTimeout, func() *resource.RetryError {
+	err = retryCallContext(ctx, func() *resource.RetryError {
 		task, err := vapp.Delete()
 		if err != nil {
-			return resource.RetryableError(fmt.Errorf("Error deleting: %#v", err))
+			return retryOnTransientError(fmt.Errorf("Error deleting: %w", err))
 		}
 
-		return resource.RetryableError(task.WaitTaskCompletion())
+		return retryOnTransientError(task.WaitTaskCompletionContext(ctx))
 	})
+	vcdClient.invalidateVdc(vdc)
 
 	return err
 }
@@ -0,0 +1,112 @@
+package vcd
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	govcd "github.com/vmware/go-vcloud-director/govcd"
+	types "github.com/vmware/go-vcloud-director/types/v56"
+)
+
+func resourceVcdVAppAccess() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceVcdVAppAccessUpdate,
+		Read:   resourceVcdVAppAccessRead,
+		Update: resourceVcdVAppAccessUpdate,
+		Delete: resourceVcdVAppAccessDelete,
+
+		Schema: accessControlSchema(map[string]*schema.Schema{
+			"org": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+
+			"vdc": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+
+			"vapp": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+		}, validateAccessLevel),
+	}
+}
+
+func resourceVcdVAppAccessUpdate(d *schema.ResourceData, meta interface{}) error {
+	vcdClient := meta.(*VCDClient)
+
+	vapp, err := getAccessVApp(d, vcdClient)
+	if err != nil {
+		return err
+	}
+
+	params, err := expandControlAccessParams(d, vcdClient)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Setting access control on vApp %s", d.Get("vapp").(string))
+	_, err = vapp.SetAccessControl(params)
+	if err != nil {
+		return fmt.Errorf("Error setting access control on vApp %s: %#v", d.Get("vapp").(string), err)
+	}
+
+	d.SetId(vapp.VApp.HREF)
+
+	return resourceVcdVAppAccessRead(d, meta)
+}
+
+func resourceVcdVAppAccessRead(d *schema.ResourceData, meta interface{}) error {
+	vcdClient := meta.(*VCDClient)
+
+	vapp, err := getAccessVApp(d, vcdClient)
+	if err != nil {
+		return err
+	}
+
+	params, err := vapp.GetAccessControl()
+	if err != nil {
+		return fmt.Errorf("Error reading access control of vApp %s: %#v", d.Get("vapp").(string), err)
+	}
+	flattenControlAccessParams(d, params)
+
+	vcdClient.setOrgAndVdcNames(d)
+
+	return nil
+}
+
+func resourceVcdVAppAccessDelete(d *schema.ResourceData, meta interface{}) error {
+	vcdClient := meta.(*VCDClient)
+
+	vapp, err := getAccessVApp(d, vcdClient)
+	if err != nil {
+		return err
+	}
+
+	_, err = vapp.SetAccessControl(&types.ControlAccessParams{IsSharedToEveryone: false})
+	if err != nil {
+		return fmt.Errorf("Error removing access control from vApp %s: %#v", d.Get("vapp").(string), err)
+	}
+
+	return nil
+}
+
+func getAccessVApp(d *schema.ResourceData, vcdClient *VCDClient) (govcd.VApp, error) {
+	_, vdc, err := vcdClient.getOrgAndVdc(d)
+	if err != nil {
+		return govcd.VApp{}, err
+	}
+	vapp, err := vdc.FindVAppByName(d.Get("vapp").(string))
+	if err != nil {
+		return govcd.VApp{}, fmt.Errorf("Could not find vApp: %s with error %v", d.Get("vapp").(string), err)
+	}
+	return vapp, nil
+}
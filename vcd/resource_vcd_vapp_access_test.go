@@ -0,0 +1,105 @@
+package vcd
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccVcdVAppAccess_Basic(t *testing.T) {
+	testAccSkipOnMock(t, "access control")
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckVcdVAppDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckVcdVAppAccess_basic, testOrg, testVDC, os.Getenv("VCD_EDGE_GATEWAY"), testOrg, testVDC, testOrg, testVDC),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"vcd_vapp_access.everyone", "everyone", "true"),
+					resource.TestCheckResourceAttr(
+						"vcd_vapp_access.everyone", "everyone_access_level", "Change"),
+				),
+			},
+		},
+	})
+}
+
+// vApp access control, unlike VDC access control, works with the API
+// versions before 27.0
+func TestAccVcdVAppAccess_APIVersion55(t *testing.T) {
+	t.Setenv("VCD_API_VERSION", "5.5")
+	catalog, template := testAccVAppTemplate(t, "tf-acc-accessvapp-template")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckVcdVAppDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckVcdVAppAccess_instantiated, testOrg, testVDC, catalog, template),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"vcd_vapp_access.everyone", "everyone", "true"),
+					resource.TestCheckResourceAttr(
+						"vcd_vapp_access.everyone", "everyone_access_level", "Change"),
+				),
+			},
+		},
+	})
+}
+
+const testAccCheckVcdVAppAccess_basic = `
+resource "vcd_network" "accessnet" {
+  org = "%s"
+  vdc = "%s"
+  name         = "tf-acc-accessnet"
+  edge_gateway = "%s"
+  gateway      = "10.10.103.1"
+
+  static_ip_pool {
+    start_address = "10.10.103.2"
+    end_address   = "10.10.103.254"
+  }
+}
+
+resource "vcd_vapp" "accessvapp" {
+  org = "%s"
+  vdc = "%s"
+  name          = "tf-acc-accessvapp"
+  template_name = "Skyscape_CentOS_6_4_x64_50GB_Small_v1.0.1"
+  catalog_name  = "Skyscape Catalogue"
+  network_name  = "${vcd_network.accessnet.name}"
+  memory        = 1024
+  cpus          = 1
+}
+
+resource "vcd_vapp_access" "everyone" {
+  org                   = "%s"
+  vdc                   = "%s"
+  vapp                  = "${vcd_vapp.accessvapp.name}"
+  everyone              = true
+  everyone_access_level = "Change"
+}
+`
+
+const testAccCheckVcdVAppAccess_instantiated = `
+resource "vcd_vapp" "accessvapp" {
+  org           = "%s"
+  vdc           = "%s"
+  name          = "tf-acc-accessvapp"
+  catalog_name  = "%s"
+  template_name = "%s"
+  instantiate   = true
+  power_on      = false
+}
+
+resource "vcd_vapp_access" "everyone" {
+  vapp                  = "${vcd_vapp.accessvapp.name}"
+  everyone              = true
+  everyone_access_level = "Change"
+}
+`
@@ -11,6 +11,7 @@ import (
 )
 
 func TestAccVcdVAppRaw_Basic(t *testing.T) {
+	testAccSkipOnMock(t, "VMs from catalog templates")
 	var vapp govcd.VApp
 
 	resource.Test(t, resource.TestCase{
@@ -23,7 +24,7 @@ func TestAccVcdVAppRaw_Basic(t *testing.T) {
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckVcdVAppRawExists("vcd_vapp.foobar", &vapp),
 					resource.TestCheckResourceAttr(
-						"vcd_vapp.foobar", "name", "foobar"),
+						"vcd_vapp.foobar", "name", "tf-acc-foobar"),
 				),
 			},
 		},
@@ -90,7 +91,7 @@ func testAccCheckVcdVAppRawDestroy(s *terraform.State) error {
 
 const testAccCheckVcdVAppRaw_basic = `
 resource "vcd_network" "foonet" {
-	name = "foonet"
+	name = "tf-acc-foonet"
 	org          = "%s"
 	vdc          = "%s"
 	edge_gateway = "%s"
@@ -104,7 +105,7 @@ resource "vcd_network" "foonet" {
 resource "vcd_vapp" "foobar" {
   org          = "%s"
   vdc          = "%s"
-  name = "foobar"
+  name = "tf-acc-foobar"
 }
 
 resource "vcd_vapp_vm" "moo" {
@@ -1,8 +1,11 @@
 package vcd
 
 import (
+	"context"
 	"fmt"
+	"log"
 	"os"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform/helper/resource"
@@ -10,7 +13,62 @@ import (
 	govcd "github.com/vmware/go-vcloud-director/govcd"
 )
 
+func init() {
+	resource.AddTestSweepers("vcd_vapp", &resource.Sweeper{
+		Name: "vcd_vapp",
+		F:    testSweepVcdVApp,
+	})
+}
+
+// Deletes the vApps with the test prefix in the VDC of the tests, found
+// through the query service.
+func testSweepVcdVApp(org string) error {
+	client, vdc, err := sweeperVdc(org)
+	if err != nil {
+		return err
+	}
+	results, err := client.QueryRecords(govcd.QueryParams{
+		Type:   "vApp",
+		Filter: "name==" + testAccPrefix + "*",
+	})
+	if err != nil {
+		return fmt.Errorf("error querying vApps: %s", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), sweepTimeout)
+	defer cancel()
+	for _, record := range results.Results.VAppRecord {
+		if record.VdcHREF != vdc.Vdc.HREF || !isTestAccName(record.Name) {
+			continue
+		}
+		log.Printf("[INFO] Sweeping vApp %s", record.Name)
+		vapp, err := vdc.FindVAppByName(record.Name)
+		if err != nil {
+			return fmt.Errorf("error finding vApp %s: %s", record.Name, err)
+		}
+		_ = retryCallContext(ctx, func() *resource.RetryError {
+			task, err := vapp.Undeploy()
+			if err != nil {
+				return retryOnTransientError(fmt.Errorf("error undeploying: %w", err))
+			}
+			return retryOnTransientError(task.WaitTaskCompletionContext(ctx))
+		})
+		err = retryCallContext(ctx, func() *resource.RetryError {
+			task, err := vapp.Delete()
+			if err != nil {
+				return retryOnTransientError(fmt.Errorf("error deleting: %w", err))
+			}
+			return retryOnTransientError(task.WaitTaskCompletionContext(ctx))
+		})
+		if err != nil {
+			return fmt.Errorf("error sweeping vApp %s: %s", record.Name, err)
+		}
+	}
+	return nil
+}
+
 func TestAccVcdVApp_PowerOff(t *testing.T) {
+	testAccSkipOnMock(t, "vApps from catalog templates")
 	var vapp govcd.VApp
 
 	resource.Test(t, resource.TestCase{
@@ -24,7 +82,7 @@ func TestAccVcdVApp_PowerOff(t *testing.T) {
 					testAccCheckVcdVAppExists("vcd_vapp.foobar", &vapp),
 					testAccCheckVcdVAppAttributes(&vapp),
 					resource.TestCheckResourceAttr(
-						"vcd_vapp.foobar", "name", "foobar"),
+						"vcd_vapp.foobar", "name", "tf-acc-foobar"),
 					resource.TestCheckResourceAttr(
 						"vcd_vapp.foobar", "ip", "10.10.102.160"),
 					resource.TestCheckResourceAttr(
@@ -36,7 +94,7 @@ func TestAccVcdVApp_PowerOff(t *testing.T) {
 				Config: fmt.Sprintf(testAccCheckVcdVApp_basic, testOrg, testVDC, os.Getenv("VCD_EDGE_GATEWAY"), testOrg, testVDC, os.Getenv("VCD_EDGE_GATEWAY"), testOrg, testVDC, testOrg, testVDC),
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttr(
-						"vcd_vapp.foobar_allocated", "name", "foobar-allocated"),
+						"vcd_vapp.foobar_allocated", "name", "tf-acc-foobar-allocated"),
 					resource.TestCheckResourceAttr(
 						"vcd_vapp.foobar_allocated", "ip", "allocated"),
 					resource.TestCheckResourceAttr(
@@ -50,7 +108,7 @@ func TestAccVcdVApp_PowerOff(t *testing.T) {
 					testAccCheckVcdVAppExists("vcd_vapp.foobar", &vapp),
 					testAccCheckVcdVAppAttributes_off(&vapp),
 					resource.TestCheckResourceAttr(
-						"vcd_vapp.foobar", "name", "foobar"),
+						"vcd_vapp.foobar", "name", "tf-acc-foobar"),
 					resource.TestCheckResourceAttr(
 						"vcd_vapp.foobar", "ip", "10.10.103.160"),
 					resource.TestCheckResourceAttr(
@@ -61,6 +119,83 @@ func TestAccVcdVApp_PowerOff(t *testing.T) {
 	})
 }
 
+func TestAccVcdVApp_Instantiate(t *testing.T) {
+	var vapp govcd.VApp
+	var powerOffs int
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccMockObjects(t, nil, map[string][]string{
+				"tf-acc-appliance": {"web", "db"},
+			})
+			testMockVCD.lock.Lock()
+			powerOffs = testMockVCD.powerOffs
+			testMockVCD.lock.Unlock()
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckVcdVAppDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config:      fmt.Sprintf(testAccCheckVcdVApp_instantiateConflict, mockVCDCatalog),
+				ExpectError: regexp.MustCompile(`memory.*conflicts with instantiate`),
+			},
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckVcdVApp_instantiate, testOrg, testVDC, os.Getenv("VCD_EDGE_GATEWAY"), mockVCDCatalog, mockVCDVAppNetwork),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVcdVAppExists("vcd_vapp.appliance", &vapp),
+					resource.TestCheckResourceAttr(
+						"vcd_vapp.appliance", "org", testOrg),
+					resource.TestCheckResourceAttr(
+						"vcd_vapp.appliance", "vdc", testVDC),
+					resource.TestCheckResourceAttr(
+						"vcd_vapp.appliance", "vms.#", "2"),
+					resource.TestCheckResourceAttr(
+						"vcd_vapp.appliance", "vms.0.name", "tf-acc-appliance-web"),
+					resource.TestCheckResourceAttr(
+						"vcd_vapp.appliance", "vms.0.cpus", "4"),
+					resource.TestCheckResourceAttr(
+						"vcd_vapp.appliance", "vms.0.memory", "2048"),
+					resource.TestCheckResourceAttr(
+						"vcd_vapp.appliance", "vms.0.ip", "10.10.104.20"),
+					resource.TestCheckResourceAttr(
+						"vcd_vapp.appliance", "vms.0.status", "POWERED_ON"),
+					resource.TestCheckResourceAttr(
+						"vcd_vapp.appliance", "vms.1.name", "db"),
+					resource.TestCheckResourceAttr(
+						"vcd_vapp.appliance", "vms.1.cpus", "2"),
+					testAccCheckVcdVAppNetworks(&vapp, "tf-acc-appliance-net", mockVCDVAppNetwork),
+					func(s *terraform.State) error {
+						testMockVCD.lock.Lock()
+						defer testMockVCD.lock.Unlock()
+						if testMockVCD.powerOffs != powerOffs {
+							return fmt.Errorf("vApp was powered off while being created")
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+// Checks that the vApp has the vApp networks called names.
+func testAccCheckVcdVAppNetworks(vapp *govcd.VApp, names ...string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		networks := make(map[string]bool)
+		if vapp.VApp.NetworkConfigSection != nil {
+			for _, network := range vapp.VApp.NetworkConfigSection.NetworkConfig {
+				networks[network.NetworkName] = true
+			}
+		}
+		for _, name := range names {
+			if !networks[name] {
+				return fmt.Errorf("vApp %s has no network %s", vapp.VApp.Name, name)
+			}
+		}
+		return nil
+	}
+}
+
 func testAccCheckVcdVAppExists(n string, vapp *govcd.VApp) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[n]
@@ -122,7 +257,7 @@ func testAccCheckVcdVAppDestroy(s *terraform.State) error {
 func testAccCheckVcdVAppAttributes(vapp *govcd.VApp) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 
-		if vapp.VApp.Name != "foobar" {
+		if vapp.VApp.Name != "tf-acc-foobar" {
 			return fmt.Errorf("Bad name: %s", vapp.VApp.Name)
 		}
 
@@ -143,7 +278,7 @@ func testAccCheckVcdVAppAttributes(vapp *govcd.VApp) resource.TestCheckFunc {
 func testAccCheckVcdVAppAttributes_off(vapp *govcd.VApp) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 
-		if vapp.VApp.Name != "foobar" {
+		if vapp.VApp.Name != "tf-acc-foobar" {
 			return fmt.Errorf("Bad name: %s", vapp.VApp.Name)
 		}
 
@@ -163,7 +298,7 @@ func testAccCheckVcdVAppAttributes_off(vapp *govcd.VApp) resource.TestCheckFunc
 
 const testAccCheckVcdVApp_basic = `
 resource "vcd_network" "foonet" {
-	name = "foonet"
+	name = "tf-acc-foonet"
 	org = "%s"
 	vdc = "%s"
 	edge_gateway = "%s"
@@ -175,7 +310,7 @@ resource "vcd_network" "foonet" {
 }
 
 resource "vcd_network" "foonet3" {
-	name = "foonet3"
+	name = "tf-acc-foonet3"
 	org = "%s"
 	vdc = "%s"
 	edge_gateway = "%s"
@@ -189,7 +324,7 @@ resource "vcd_network" "foonet3" {
 resource "vcd_vapp" "foobar" {
   org = "%s"
   vdc = "%s"
-  name          = "foobar"
+  name          = "tf-acc-foobar"
   template_name = "Skyscape_CentOS_6_4_x64_50GB_Small_v1.0.1"
   catalog_name  = "Skyscape Catalogue"
   network_name  = "${vcd_network.foonet.name}"
@@ -201,7 +336,7 @@ resource "vcd_vapp" "foobar" {
 resource "vcd_vapp" "foobar_allocated" {
   org = "%s"
   vdc = "%s"
-  name          = "foobar-allocated"
+  name          = "tf-acc-foobar-allocated"
   template_name = "Skyscape_CentOS_6_4_x64_50GB_Small_v1.0.1"
   catalog_name  = "Skyscape Catalogue"
   network_name  = "${vcd_network.foonet3.name}"
@@ -215,7 +350,7 @@ const testAccCheckVcdVApp_powerOff = `
 resource "vcd_network" "foonet2" {
 	org = "%s"
 	vdc = "%s"
-	name = "foonet2"
+	name = "tf-acc-foonet2"
 	edge_gateway = "%s"
 	gateway = "10.10.103.1"
 	static_ip_pool {
@@ -232,7 +367,7 @@ resource "vcd_network" "foonet2" {
 resource "vcd_vapp" "foobar" {
   org = "%s"
   vdc = "%s"
-  name          = "foobar"
+  name          = "tf-acc-foobar"
   template_name = "Skyscape_CentOS_6_4_x64_50GB_Small_v1.0.1"
   catalog_name  = "Skyscape Catalogue"
   network_name  = "${vcd_network.foonet2.name}"
@@ -242,3 +377,51 @@ resource "vcd_vapp" "foobar" {
   power_on      = false
 }
 `
+
+const testAccCheckVcdVApp_instantiate = `
+resource "vcd_network" "appliance" {
+	org = "%s"
+	vdc = "%s"
+	name = "tf-acc-appliance-net"
+	edge_gateway = "%s"
+	gateway = "10.10.104.1"
+	static_ip_pool {
+		start_address = "10.10.104.2"
+		end_address = "10.10.104.254"
+	}
+}
+
+# org and vdc default to those of the provider
+resource "vcd_vapp" "appliance" {
+  name          = "tf-acc-appliance"
+  catalog_name  = "%s"
+  template_name = "tf-acc-appliance"
+  instantiate   = true
+
+  vm {
+    template_vm_name = "web"
+    name             = "tf-acc-appliance-web"
+    cpus             = 4
+    memory           = 2048
+
+    networks {
+      name = "${vcd_network.appliance.name}"
+      ip   = "10.10.104.20"
+    }
+
+    networks {
+      name = "%s"
+    }
+  }
+}
+`
+
+const testAccCheckVcdVApp_instantiateConflict = `
+resource "vcd_vapp" "appliance" {
+  name          = "tf-acc-appliance"
+  catalog_name  = "%s"
+  template_name = "tf-acc-appliance"
+  instantiate   = true
+  memory        = 2048
+}
+`
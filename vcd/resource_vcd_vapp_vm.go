@@ -7,6 +7,7 @@ import (
 	govcd "github.com/vmware/go-vcloud-director/govcd"
 	types "github.com/vmware/go-vcloud-director/types/v56"
 	"log"
+	"time"
 )
 
 func resourceVcdVAppVm() *schema.Resource {
@@ -16,6 +17,12 @@ func resourceVcdVAppVm() *schema.Resource {
 		Read:   resourceVcdVAppVmRead,
 		Delete: resourceVcdVAppVmDelete,
 
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+			Update: schema.DefaultTimeout(30 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+
 		Schema: map[string]*schema.Schema{
 			"vapp_name": &schema.Schema{
 				Type:     schema.TypeString,
@@ -30,12 +37,14 @@ func resourceVcdVAppVm() *schema.Resource {
 			},
 			"org": {
 				Type:     schema.TypeString,
-				Required: true,
+				Optional: true,
+				Computed: true,
 				ForceNew: true,
 			},
 			"vdc": {
 				Type:     schema.TypeString,
-				Required: true,
+				Optional: true,
+				Computed: true,
 				ForceNew: true,
 			},
 			"template_name": &schema.Schema{
@@ -50,6 +59,13 @@ func resourceVcdVAppVm() *schema.Resource {
 				ForceNew: true,
 			},
 
+			"template_vm_name": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The VM of the template to add. Defaults to its first VM",
+			},
+
 			"memory": &schema.Schema{
 				Type:     schema.TypeInt,
 				Optional: true,
@@ -100,21 +116,14 @@ func resourceVcdVAppVm() *schema.Resource {
 
 func resourceVcdVAppVmCreate(d *schema.ResourceData, meta interface{}) error {
 	vcdClient := meta.(*VCDClient)
-	org, err := govcd.GetOrgByName(vcdClient.VCDClient, d.Get("org").(string))
+	ctx, cancel := operationContext(d, schema.TimeoutCreate)
+	defer cancel()
+
+	org, vdc, err := vcdClient.getOrgAndVdc(d)
 	if err != nil {
-		return fmt.Errorf("Could not get Org: %s with error %v", d.Get("org").(string), err)
-	}
-	if org == (govcd.Org{}) {
-		return fmt.Errorf("Could not find Org: %s", d.Get("org").(string))
+		return err
 	}
-	vdc, err := org.GetVdcByName(d.Get("vdc").(string))
-	if err != nil || vdc == (govcd.Vdc{}) {
-		return fmt.Errorf("Could not get vdc: %s with error %v", d.Get("vdc").(string), err)
-	}
-	if vdc == (govcd.Vdc{}) {
-		return fmt.Errorf("Could not find vdc: %s", d.Get("vdc").(string))
-	}
-	catalog, err := org.FindCatalog(d.Get("catalog_name").(string))
+	catalog, err := vcdClient.getCatalog(org, d.Get("catalog_name").(string))
 	if err != nil || catalog == (govcd.Catalog{}) {
 		return fmt.Errorf("Error finding catalog: %s", d.Get("catalog_name").(string))
 	}
@@ -165,12 +174,12 @@ func resourceVcdVAppVmCreate(d *schema.ResourceData, meta interface{}) error {
 			return fmt.Errorf("'network_name' must be valid when adding VM to raw vapp")
 		}
 
-		err = retryCall(vcdClient.MaxRetryTimeout, func() *resource.RetryError {
+		err = retryCallContext(ctx, func() *resource.RetryError {
 			task, err := vapp.AddRAWNetworkConfig(nets)
 			if err != nil {
-				return resource.RetryableError(fmt.Errorf("Error assigning network to vApp: %#v", err))
+				return retryOnTransientError(fmt.Errorf("Error assigning network to vApp: %w", err))
 			}
-			return resource.RetryableError(task.WaitTaskCompletion())
+			return retryOnTransientError(task.WaitTaskCompletionContext(ctx))
 		})
 
 		if err != nil {
@@ -187,15 +196,15 @@ func resourceVcdVAppVmCreate(d *schema.ResourceData, meta interface{}) error {
 
 	log.Printf("[TRACE] Network name found: %s", netname)
 
-	err = retryCall(vcdClient.MaxRetryTimeout, func() *resource.RetryError {
+	err = retryCallContext(ctx, func() *resource.RetryError {
 		log.Printf("[TRACE] Creating VM: %s", d.Get("name").(string))
-		task, err := vapp.AddVM(nets, vapptemplate, d.Get("name").(string), accept_eulas)
+		task, err := vapp.AddVM(nets, vapptemplate, d.Get("template_vm_name").(string), d.Get("name").(string), accept_eulas)
 
 		if err != nil {
-			return resource.RetryableError(fmt.Errorf("Error adding VM: %#v", err))
+			return retryOnTransientError(fmt.Errorf("Error adding VM: %w", err))
 		}
 
-		return resource.RetryableError(task.WaitTaskCompletion())
+		return retryOnTransientError(task.WaitTaskCompletionContext(ctx))
 	})
 
 	if err != nil {
@@ -209,7 +218,7 @@ func resourceVcdVAppVmCreate(d *schema.ResourceData, meta interface{}) error {
 		return fmt.Errorf("Error getting VM1 : %#v", err)
 	}
 
-	err = retryCall(vcdClient.MaxRetryTimeout, func() *resource.RetryError {
+	err = retryCallContext(ctx, func() *resource.RetryError {
 		networks := []map[string]interface{}{map[string]interface{}{
 			"ip":         d.Get("ip").(string),
 			"is_primary": true,
@@ -217,9 +226,9 @@ func resourceVcdVAppVmCreate(d *schema.ResourceData, meta interface{}) error {
 		}}
 		task, err := vm.ChangeNetworkConfig(networks, d.Get("ip").(string))
 		if err != nil {
-			return resource.RetryableError(fmt.Errorf("Error with Networking change: %#v", err))
+			return retryOnTransientError(fmt.Errorf("Error with Networking change: %w", err))
 		}
-		return resource.RetryableError(task.WaitTaskCompletion())
+		return retryOnTransientError(task.WaitTaskCompletionContext(ctx))
 	})
 	if err != nil {
 		return fmt.Errorf("Error changing network: %#v", err)
@@ -228,12 +237,12 @@ func resourceVcdVAppVmCreate(d *schema.ResourceData, meta interface{}) error {
 	initscript, ok := d.GetOk("initscript")
 
 	if ok {
-		err = retryCall(vcdClient.MaxRetryTimeout, func() *resource.RetryError {
+		err = retryCallContext(ctx, func() *resource.RetryError {
 			task, err := vm.RunCustomizationScript(d.Get("name").(string), initscript.(string))
 			if err != nil {
-				return resource.RetryableError(fmt.Errorf("Error with setting init script: %#v", err))
+				return retryOnTransientError(fmt.Errorf("Error with setting init script: %w", err))
 			}
-			return resource.RetryableError(task.WaitTaskCompletion())
+			return retryOnTransientError(task.WaitTaskCompletionContext(ctx))
 		})
 		if err != nil {
 			return fmt.Errorf("Error completing tasks: %#v", err)
@@ -247,20 +256,12 @@ func resourceVcdVAppVmCreate(d *schema.ResourceData, meta interface{}) error {
 func resourceVcdVAppVmUpdate(d *schema.ResourceData, meta interface{}) error {
 
 	vcdClient := meta.(*VCDClient)
+	ctx, cancel := operationContext(d, schema.TimeoutUpdate)
+	defer cancel()
 
-	org, err := govcd.GetOrgByName(vcdClient.VCDClient, d.Get("org").(string))
+	_, vdc, err := vcdClient.getOrgAndVdc(d)
 	if err != nil {
-		return fmt.Errorf("Could not get Org: %s with error %v", d.Get("org").(string), err)
-	}
-	if org == (govcd.Org{}) {
-		return fmt.Errorf("Could not find Org: %s", d.Get("org").(string))
-	}
-	vdc, err := org.GetVdcByName(d.Get("vdc").(string))
-	if err != nil || vdc == (govcd.Vdc{}) {
-		return fmt.Errorf("Could not get vdc: %s with error %v", d.Get("vdc").(string), err)
-	}
-	if vdc == (govcd.Vdc{}) {
-		return fmt.Errorf("Could not find vdc: %s", d.Get("vdc").(string))
+		return err
 	}
 
 	vapp, err := vdc.FindVAppByName(d.Get("vapp_name").(string))
@@ -287,20 +288,20 @@ func resourceVcdVAppVmUpdate(d *schema.ResourceData, meta interface{}) error {
 			if err != nil {
 				return fmt.Errorf("Error Powering Off: %#v", err)
 			}
-			err = task.WaitTaskCompletion()
+			err = task.WaitTaskCompletionContext(ctx)
 			if err != nil {
 				return fmt.Errorf("Error completing tasks: %#v", err)
 			}
 		}
 
 		if d.HasChange("memory") {
-			err = retryCall(vcdClient.MaxRetryTimeout, func() *resource.RetryError {
+			err = retryCallContext(ctx, func() *resource.RetryError {
 				task, err := vm.ChangeMemorySize(d.Get("memory").(int))
 				if err != nil {
-					return resource.RetryableError(fmt.Errorf("Error changing memory size: %#v", err))
+					return retryOnTransientError(fmt.Errorf("Error changing memory size: %w", err))
 				}
 
-				return resource.RetryableError(task.WaitTaskCompletion())
+				return retryOnTransientError(task.WaitTaskCompletionContext(ctx))
 			})
 			if err != nil {
 				return err
@@ -308,13 +309,13 @@ func resourceVcdVAppVmUpdate(d *schema.ResourceData, meta interface{}) error {
 		}
 
 		if d.HasChange("cpus") {
-			err = retryCall(vcdClient.MaxRetryTimeout, func() *resource.RetryError {
+			err = retryCallContext(ctx, func() *resource.RetryError {
 				task, err := vm.ChangeCPUcount(d.Get("cpus").(int))
 				if err != nil {
-					return resource.RetryableError(fmt.Errorf("Error changing cpu count: %#v", err))
+					return retryOnTransientError(fmt.Errorf("Error changing cpu count: %w", err))
 				}
 
-				return resource.RetryableError(task.WaitTaskCompletion())
+				return retryOnTransientError(task.WaitTaskCompletionContext(ctx))
 			})
 			if err != nil {
 				return fmt.Errorf("Error completing task: %#v", err)
@@ -326,7 +327,7 @@ func resourceVcdVAppVmUpdate(d *schema.ResourceData, meta interface{}) error {
 			if err != nil {
 				return fmt.Errorf("Error Powering Up: %#v", err)
 			}
-			err = task.WaitTaskCompletion()
+			err = task.WaitTaskCompletionContext(ctx)
 			if err != nil {
 				return fmt.Errorf("Error completing tasks: %#v", err)
 			}
@@ -340,19 +341,9 @@ func resourceVcdVAppVmUpdate(d *schema.ResourceData, meta interface{}) error {
 func resourceVcdVAppVmRead(d *schema.ResourceData, meta interface{}) error {
 	vcdClient := meta.(*VCDClient)
 
-	org, err := govcd.GetOrgByName(vcdClient.VCDClient, d.Get("org").(string))
+	_, vdc, err := vcdClient.getOrgAndVdc(d)
 	if err != nil {
-		return fmt.Errorf("Could not get Org: %s with error %v", d.Get("org").(string), err)
-	}
-	if org == (govcd.Org{}) {
-		return fmt.Errorf("Could not find Org: %s", d.Get("org").(string))
-	}
-	vdc, err := org.GetVdcByName(d.Get("vdc").(string))
-	if err != nil || vdc == (govcd.Vdc{}) {
-		return fmt.Errorf("Could not get vdc: %s with error %v", d.Get("vdc").(string), err)
-	}
-	if vdc == (govcd.Vdc{}) {
-		return fmt.Errorf("Could not find vdc: %s", d.Get("vdc").(string))
+		return err
 	}
 
 	vapp, err := vdc.FindVAppByName(d.Get("vapp_name").(string))
@@ -372,25 +363,19 @@ func resourceVcdVAppVmRead(d *schema.ResourceData, meta interface{}) error {
 	d.Set("ip", vm.VM.NetworkConnectionSection.NetworkConnection[0].IPAddress)
 	d.Set("href", vm.VM.HREF)
 
+	vcdClient.setOrgAndVdcNames(d)
+
 	return nil
 }
 
 func resourceVcdVAppVmDelete(d *schema.ResourceData, meta interface{}) error {
 	vcdClient := meta.(*VCDClient)
+	ctx, cancel := operationContext(d, schema.TimeoutDelete)
+	defer cancel()
 
-	org, err := govcd.GetOrgByName(vcdClient.VCDClient, d.Get("org").(string))
+	_, vdc, err := vcdClient.getOrgAndVdc(d)
 	if err != nil {
-		return fmt.Errorf("Could not get Org: %s with error %v", d.Get("org").(string), err)
-	}
-	if org == (govcd.Org{}) {
-		return fmt.Errorf("Could not find Org: %s", d.Get("org").(string))
-	}
-	vdc, err := org.GetVdcByName(d.Get("vdc").(string))
-	if err != nil || vdc == (govcd.Vdc{}) {
-		return fmt.Errorf("Could not get vdc: %s with error %v", d.Get("vdc").(string), err)
-	}
-	if vdc == (govcd.Vdc{}) {
-		return fmt.Errorf("Could not find vdc: %s", d.Get("vdc").(string))
+		return err
 	}
 
 	vapp, err := vdc.FindVAppByName(d.Get("vapp_name").(string))
@@ -417,17 +402,17 @@ func resourceVcdVAppVmDelete(d *schema.ResourceData, meta interface{}) error {
 		if err != nil {
 			return fmt.Errorf("Error Undeploying vApp: %#v", err)
 		}
-		err = task.WaitTaskCompletion()
+		err = task.WaitTaskCompletionContext(ctx)
 		if err != nil {
 			return fmt.Errorf("Error completing tasks: %#v", err)
 		}
 	}
 
-	err = retryCall(vcdClient.MaxRetryTimeout, func() *resource.RetryError {
+	err = retryCallContext(ctx, func() *resource.RetryError {
 		log.Printf("[TRACE] Removing VM: %s", vm.VM.Name)
 		err := vapp.RemoveVM(vm)
 		if err != nil {
-			return resource.RetryableError(fmt.Errorf("Error deleting: %#v", err))
+			return retryOnTransientError(fmt.Errorf("Error deleting: %w", err))
 		}
 
 		return nil
@@ -439,7 +424,7 @@ func resourceVcdVAppVmDelete(d *schema.ResourceData, meta interface{}) error {
 		if err != nil {
 			return fmt.Errorf("Error Deploying vApp: %#v", err)
 		}
-		err = task.WaitTaskCompletion()
+		err = task.WaitTaskCompletionContext(ctx)
 		if err != nil {
 			return fmt.Errorf("Error completing tasks: %#v", err)
 		}
@@ -449,7 +434,7 @@ func resourceVcdVAppVmDelete(d *schema.ResourceData, meta interface{}) error {
 		if err != nil {
 			return fmt.Errorf("Error Powering on vApp: %#v", err)
 		}
-		err = task.WaitTaskCompletion()
+		err = task.WaitTaskCompletionContext(ctx)
 		if err != nil {
 			return fmt.Errorf("Error completing tasks: %#v", err)
 		}
@@ -11,6 +11,7 @@ import (
 )
 
 func TestAccVcdVAppVm_Basic(t *testing.T) {
+	testAccSkipOnMock(t, "VMs from catalog templates")
 	var vapp govcd.VApp
 	var vm govcd.VM
 
@@ -55,7 +56,7 @@ func testAccCheckVcdVAppVmExists(n string, vapp *govcd.VApp, vm *govcd.VM) resou
 		if err != nil || vdc == (govcd.Vdc{}) {
 			return fmt.Errorf("Could not find test Vdc")
 		}
-		vapp, err := vdc.FindVAppByName("foobar")
+		vapp, err := vdc.FindVAppByName("tf-acc-foobar")
 
 		resp, err := vdc.FindVMByName(vapp, "moo")
 
@@ -84,7 +85,7 @@ func testAccCheckVcdVAppVmDestroy(s *terraform.State) error {
 		if err != nil || vdc == (govcd.Vdc{}) {
 			return fmt.Errorf("Could not find test Vdc")
 		}
-		_, err = vdc.FindVAppByName("foobar")
+		_, err = vdc.FindVAppByName("tf-acc-foobar")
 
 		if err == nil {
 			return fmt.Errorf("VPCs still exist")
@@ -98,7 +99,7 @@ func testAccCheckVcdVAppVmDestroy(s *terraform.State) error {
 
 const testAccCheckVcdVAppVm_basic = `
 resource "vcd_network" "foonet" {
-	name = "foonet"
+	name = "tf-acc-foonet"
 	org = "%s"
 	vdc = "%s"
 	edge_gateway = "%s"
@@ -110,7 +111,7 @@ resource "vcd_network" "foonet" {
 }
 
 resource "vcd_vapp" "foobar" {
-  name          = "foobar"
+  name          = "tf-acc-foobar"
   org = "%s"
   vdc = "%s"
   template_name = "Skyscape_CentOS_6_4_x64_50GB_Small_v1.0.1"
@@ -0,0 +1,103 @@
+package vcd
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	govcd "github.com/vmware/go-vcloud-director/govcd"
+	types "github.com/vmware/go-vcloud-director/types/v56"
+)
+
+func resourceVcdVdcAccess() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceVcdVdcAccessUpdate,
+		Read:   resourceVcdVdcAccessRead,
+		Update: resourceVcdVdcAccessUpdate,
+		Delete: resourceVcdVdcAccessDelete,
+
+		Schema: accessControlSchema(map[string]*schema.Schema{
+			"org": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+
+			"vdc": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+		}, validateVdcAccessLevel),
+	}
+}
+
+func resourceVcdVdcAccessUpdate(d *schema.ResourceData, meta interface{}) error {
+	vcdClient := meta.(*VCDClient)
+
+	if err := vcdClient.Client.CheckAPIVersion(govcd.APIVersion820, "VDC access control"); err != nil {
+		return err
+	}
+	_, vdc, err := vcdClient.getOrgAndVdc(d)
+	if err != nil {
+		return err
+	}
+
+	params, err := expandControlAccessParams(d, vcdClient)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Setting access control on vdc %s", vcdClient.vdcName(d))
+	_, err = vdc.SetAccessControl(params)
+	if err != nil {
+		return fmt.Errorf("Error setting access control on vdc %s: %#v", vcdClient.vdcName(d), err)
+	}
+
+	d.SetId(vdc.Vdc.HREF)
+
+	return resourceVcdVdcAccessRead(d, meta)
+}
+
+func resourceVcdVdcAccessRead(d *schema.ResourceData, meta interface{}) error {
+	vcdClient := meta.(*VCDClient)
+
+	if err := vcdClient.Client.CheckAPIVersion(govcd.APIVersion820, "VDC access control"); err != nil {
+		return err
+	}
+	_, vdc, err := vcdClient.getOrgAndVdc(d)
+	if err != nil {
+		return err
+	}
+
+	params, err := vdc.GetAccessControl()
+	if err != nil {
+		return fmt.Errorf("Error reading access control of vdc %s: %#v", vcdClient.vdcName(d), err)
+	}
+	flattenControlAccessParams(d, params)
+
+	vcdClient.setOrgAndVdcNames(d)
+
+	return nil
+}
+
+func resourceVcdVdcAccessDelete(d *schema.ResourceData, meta interface{}) error {
+	vcdClient := meta.(*VCDClient)
+
+	if err := vcdClient.Client.CheckAPIVersion(govcd.APIVersion820, "VDC access control"); err != nil {
+		return err
+	}
+	_, vdc, err := vcdClient.getOrgAndVdc(d)
+	if err != nil {
+		return err
+	}
+
+	_, err = vdc.SetAccessControl(&types.ControlAccessParams{IsSharedToEveryone: false})
+	if err != nil {
+		return fmt.Errorf("Error removing access control from vdc %s: %#v", vcdClient.vdcName(d), err)
+	}
+
+	return nil
+}
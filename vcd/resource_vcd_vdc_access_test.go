@@ -0,0 +1,67 @@
+package vcd
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	govcd "github.com/vmware/go-vcloud-director/govcd"
+)
+
+func TestAccVcdVdcAccess_Basic(t *testing.T) {
+	testAccSkipOnMock(t, "access control")
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckVcdVdcAccessDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckVcdVdcAccess_basic, testOrg, testVDC),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"vcd_vdc_access.everyone", "everyone", "true"),
+					resource.TestCheckResourceAttr(
+						"vcd_vdc_access.everyone", "everyone_access_level", "ReadOnly"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckVcdVdcAccessDestroy(s *terraform.State) error {
+	conn := testAccProvider.Meta().(*VCDClient)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "vcd_vdc_access" {
+			continue
+		}
+
+		org, err := govcd.GetOrgByName(conn.VCDClient, rs.Primary.Attributes["org"])
+		if err != nil || org == (govcd.Org{}) {
+			return fmt.Errorf("Could not find test Org")
+		}
+		vdc, err := org.GetVdcByName(rs.Primary.Attributes["vdc"])
+		if err != nil || vdc == (govcd.Vdc{}) {
+			return fmt.Errorf("Could not find test Vdc")
+		}
+
+		params, err := vdc.GetAccessControl()
+		if err != nil {
+			return fmt.Errorf("Could not read access control of vdc: %#v", err)
+		}
+		if params.IsSharedToEveryone {
+			return fmt.Errorf("Vdc %s is still shared to everyone", rs.Primary.Attributes["vdc"])
+		}
+	}
+
+	return nil
+}
+
+const testAccCheckVcdVdcAccess_basic = `
+resource "vcd_vdc_access" "everyone" {
+  org      = "%s"
+  vdc      = "%s"
+  everyone = true
+}
+`
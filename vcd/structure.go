@@ -1,12 +1,15 @@
 package vcd
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"strconv"
 	"time"
 
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/helper/schema"
+	govcd "github.com/vmware/go-vcloud-director/govcd"
 	types "github.com/vmware/go-vcloud-director/types/v56"
 )
 
@@ -31,9 +34,9 @@ func expandIPRange(configured []interface{}) types.IPRanges {
 	return ipRanges
 }
 
-func expandFirewallRules(d *schema.ResourceData, gateway *types.EdgeGateway) ([]*types.FirewallRule, error) {
+func expandFirewallRules(d *schema.ResourceData, firewallService *types.FirewallService) ([]*types.FirewallRule, error) {
 	//firewallRules := make([]*types.FirewallRule, 0, len(configured))
-	firewallRules := gateway.Configuration.EdgeGatewayServiceConfiguration.FirewallService.FirewallRule
+	firewallRules := append([]*types.FirewallRule{}, firewallService.FirewallRule...)
 
 	rulesCount := d.Get("rule.#").(int)
 	for i := 0; i < rulesCount; i++ {
@@ -112,6 +115,61 @@ func retryCall(seconds int, f resource.RetryFunc) error {
 	return resource.Retry(time.Duration(seconds)*time.Second, f)
 }
 
+// isTransientError returns true for errors worth retrying: vCD reporting
+// the entity as busy with another operation, or being unavailable.
+func isTransientError(err error) bool {
+	var vcdErr *govcd.VCDError
+	if errors.As(err, &vcdErr) {
+		return vcdErr.IsBusy() || vcdErr.IsTransient()
+	}
+	return false
+}
+
+// isNotFoundError returns true for errors vCD returns for entities that
+// don't exist, which resources read to find out they were deleted.
+func isNotFoundError(err error) bool {
+	var vcdErr *govcd.VCDError
+	if errors.As(err, &vcdErr) {
+		return vcdErr.IsNotFound()
+	}
+	return false
+}
+
+// retryOnTransientError makes a retryCall function retry err when it is
+// transient, and fail straight away otherwise.
+func retryOnTransientError(err error) *resource.RetryError {
+	if err == nil {
+		return nil
+	}
+	if isTransientError(err) {
+		return resource.RetryableError(err)
+	}
+	return resource.NonRetryableError(err)
+}
+
+// operationContext returns a context that expires after the timeout of
+// the operation being run, as configured in the resource's timeouts block.
+func operationContext(d *schema.ResourceData, operation string) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.Background(), d.Timeout(operation))
+}
+
+// retryCallContext is retryCall bounded by the deadline of ctx instead of
+// a number of seconds. Once the deadline passes, a retryable error ends
+// the retries instead of starting the operation again.
+func retryCallContext(ctx context.Context, f resource.RetryFunc) error {
+	timeout := time.Duration(0)
+	if deadline, ok := ctx.Deadline(); ok {
+		timeout = time.Until(deadline)
+	}
+	return resource.Retry(timeout, func() *resource.RetryError {
+		rerr := f()
+		if rerr != nil && rerr.Retryable && ctx.Err() != nil {
+			return resource.NonRetryableError(rerr.Err)
+		}
+		return rerr
+	})
+}
+
 func convertToStringMap(param map[string]interface{}) map[string]string {
 	temp := make(map[string]string)
 	for k, v := range param {
@@ -119,3 +177,64 @@ func convertToStringMap(param map[string]interface{}) map[string]string {
 	}
 	return temp
 }
+
+func expandIPScopes(configured []interface{}) []*types.IPScope {
+	ipScopes := make([]*types.IPScope, 0, len(configured))
+
+	for _, scopeRaw := range configured {
+		data := scopeRaw.(map[string]interface{})
+
+		ipRanges := expandIPRange(data["static_ip_pool"].(*schema.Set).List())
+		ipScopes = append(ipScopes, &types.IPScope{
+			IsInherited: false,
+			IsEnabled:   true,
+			Gateway:     data["gateway"].(string),
+			Netmask:     data["netmask"].(string),
+			DNS1:        data["dns1"].(string),
+			DNS2:        data["dns2"].(string),
+			DNSSuffix:   data["dns_suffix"].(string),
+			IPRanges:    &ipRanges,
+		})
+	}
+
+	return ipScopes
+}
+
+func flattenIPScopes(ipScopes []*types.IPScope) []map[string]interface{} {
+	result := make([]map[string]interface{}, 0, len(ipScopes))
+
+	for _, ipScope := range ipScopes {
+		pools := make([]interface{}, 0)
+		if ipScope.IPRanges != nil {
+			for _, ipRange := range ipScope.IPRanges.IPRange {
+				pools = append(pools, map[string]interface{}{
+					"start_address": ipRange.StartAddress,
+					"end_address":   ipRange.EndAddress,
+				})
+			}
+		}
+
+		result = append(result, map[string]interface{}{
+			"gateway":        ipScope.Gateway,
+			"netmask":        ipScope.Netmask,
+			"dns1":           ipScope.DNS1,
+			"dns2":           ipScope.DNS2,
+			"dns_suffix":     ipScope.DNSSuffix,
+			"static_ip_pool": pools,
+		})
+	}
+
+	return result
+}
+
+// flattenMetadata returns metadata as a map of its values, which are all
+// given as strings whatever their type.
+func flattenMetadata(metadata *types.Metadata) map[string]interface{} {
+	result := make(map[string]interface{})
+	for _, entry := range metadata.MetadataEntry {
+		if entry.TypedValue != nil {
+			result[entry.Key] = entry.TypedValue.Value
+		}
+	}
+	return result
+}
@@ -0,0 +1,37 @@
+package vcd
+
+import (
+	"fmt"
+	"testing"
+
+	govcd "github.com/vmware/go-vcloud-director/govcd"
+)
+
+func TestIsTransientError(t *testing.T) {
+	cases := []struct {
+		err       error
+		transient bool
+	}{
+		{&govcd.VCDError{StatusCode: 400, MajorErrorCode: 400, MinorErrorCode: "BUSY_ENTITY",
+			Message: "The entity gw1 is busy completing an operation."}, true},
+		{fmt.Errorf("error reconfiguring Edge Gateway: %w", &govcd.VCDError{StatusCode: 503}), true},
+		{fmt.Errorf("task did not complete succesfully: %w", &govcd.VCDError{MajorErrorCode: 500,
+			Message: "Cannot perform this operation, the entity is busy"}), true},
+		{&govcd.VCDError{StatusCode: 400, MajorErrorCode: 400, MinorErrorCode: "BAD_REQUEST",
+			Message: "Invalid IP address"}, false},
+		{&govcd.VCDError{StatusCode: 403, MajorErrorCode: 403, MinorErrorCode: "ACCESS_TO_RESOURCE_IS_FORBIDDEN"}, false},
+		{fmt.Errorf("Error finding vapp"), false},
+	}
+
+	for _, c := range cases {
+		if got := isTransientError(c.err); got != c.transient {
+			t.Errorf("isTransientError(%q) = %t, expected %t", c.err, got, c.transient)
+		}
+		if rerr := retryOnTransientError(c.err); rerr.Retryable != c.transient {
+			t.Errorf("retryOnTransientError(%q) retryable = %t, expected %t", c.err, rerr.Retryable, c.transient)
+		}
+	}
+	if retryOnTransientError(nil) != nil {
+		t.Errorf("retryOnTransientError(nil) should succeed")
+	}
+}
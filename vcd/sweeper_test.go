@@ -0,0 +1,208 @@
+package vcd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	govcd "github.com/vmware/go-vcloud-director/govcd"
+	types "github.com/vmware/go-vcloud-director/types/v56"
+)
+
+// The names of the objects the acceptance tests create start with
+// testAccPrefix, so that the sweepers can find the ones failed runs leave
+// behind.
+const testAccPrefix = "tf-acc-"
+
+const sweepTimeout = 10 * time.Minute
+
+func isTestAccName(name string) bool {
+	return strings.HasPrefix(name, testAccPrefix)
+}
+
+// sharedClientForOrg returns a client to sweep org, which the -sweep flag
+// names in place of a region. The other settings are those of the
+// acceptance tests.
+func sharedClientForOrg(org string) (*VCDClient, error) {
+	config := Config{
+		User:            os.Getenv("VCD_USER"),
+		Password:        os.Getenv("VCD_PASSWORD"),
+		Org:             org,
+		Vdc:             os.Getenv("VCD_VDC"),
+		Href:            os.Getenv("VCD_URL"),
+		MaxRetryTimeout: 240,
+		InsecureFlag:    os.Getenv("VCD_ALLOW_UNVERIFIED_SSL") == "true",
+	}
+	return config.Client()
+}
+
+// sweeperVdc returns a client for org and the VDC of the acceptance tests
+// in it.
+func sweeperVdc(org string) (*VCDClient, govcd.Vdc, error) {
+	client, err := sharedClientForOrg(org)
+	if err != nil {
+		return nil, govcd.Vdc{}, fmt.Errorf("error getting client: %s", err)
+	}
+	o, err := client.getOrg(org)
+	if err != nil || o == (govcd.Org{}) {
+		return nil, govcd.Vdc{}, fmt.Errorf("could not find org %s: %v", org, err)
+	}
+	vdc, err := client.getVdc(o, client.Vdc)
+	if err != nil || vdc == (govcd.Vdc{}) {
+		return nil, govcd.Vdc{}, fmt.Errorf("could not find vdc %s: %v", client.Vdc, err)
+	}
+	return client, vdc, nil
+}
+
+// edgeGatewaySweep removes the objects of the acceptance tests from
+// config, like an edgeGatewayChange, and returns how many it removed.
+type edgeGatewaySweep func(edgeGateway *govcd.EdgeGateway, config *types.EdgeGatewayServiceConfiguration) int
+
+// sweepEdgeGateway reconfigures the edge gateway of the acceptance tests
+// in org with sweep, unless there is nothing to sweep.
+func sweepEdgeGateway(org, objects string, sweep edgeGatewaySweep) error {
+	client, vdc, err := sweeperVdc(org)
+	if err != nil {
+		return err
+	}
+	edgeGateway, err := client.getEdgeGateway(vdc, os.Getenv("VCD_EDGE_GATEWAY"))
+	if err != nil {
+		return fmt.Errorf("could not find edge gateway: %s", err)
+	}
+
+	removed := sweep(&edgeGateway, &types.EdgeGatewayServiceConfiguration{})
+	if removed == 0 {
+		return nil
+	}
+	log.Printf("[INFO] Sweeping %d %s from edge gateway %s", removed, objects, edgeGateway.EdgeGateway.Name)
+
+	ctx, cancel := context.WithTimeout(context.Background(), sweepTimeout)
+	defer cancel()
+	return client.configureEdgeGateway(ctx, edgeGateway, func(edgeGateway *govcd.EdgeGateway, config *types.EdgeGatewayServiceConfiguration) error {
+		sweep(edgeGateway, config)
+		return nil
+	})
+}
+
+// Returns a copy of natService without the rules of ruleType the
+// acceptance tests created, which have no name but a description with
+// testAccPrefix, and how many it left out.
+func sweepNatRules(natService *types.NatService, ruleType string) (*types.NatService, int) {
+	if natService == nil {
+		return nil, 0
+	}
+	swept := *natService
+	swept.NatRule = nil
+	for _, rule := range natService.NatRule {
+		if rule.RuleType == ruleType && isTestAccName(rule.Description) {
+			continue
+		}
+		swept.NatRule = append(swept.NatRule, rule)
+	}
+	return &swept, len(natService.NatRule) - len(swept.NatRule)
+}
+
+func natServiceToSweep(edgeGateway *govcd.EdgeGateway, config *types.EdgeGatewayServiceConfiguration) *types.NatService {
+	if config.NatService != nil {
+		return config.NatService
+	}
+	return edgeGateway.EdgeGateway.Configuration.EdgeGatewayServiceConfiguration.NatService
+}
+
+// TestSweepers seeds the mock vCD with objects left behind by tests and
+// next to others, and checks that sweeping leaves only the others.
+func TestSweepers(t *testing.T) {
+	if testMockVCD == nil {
+		t.Skip("Sweepers are only tested against the mock vCD")
+	}
+	m := testMockVCD
+	m.addVApp(testAccPrefix+"leaked", "vm1")
+	m.addVApp("sweeper-keep")
+	m.addNetwork(testAccPrefix + "leaked")
+	m.addNetwork("sweeper-keep")
+
+	m.lock.Lock()
+	services := m.edgeGateway.Configuration.EdgeGatewayServiceConfiguration
+	saved := *services
+	natRule := func(ruleType, description, originalIP, translatedIP string) *types.NatRule {
+		return &types.NatRule{
+			RuleType:       ruleType,
+			IsEnabled:      true,
+			Description:    description,
+			GatewayNatRule: &types.GatewayNatRule{OriginalIP: originalIP, TranslatedIP: translatedIP},
+		}
+	}
+	// The rules to keep use the network of the tests too
+	services.NatService = &types.NatService{IsEnabled: true, NatRule: []*types.NatRule{
+		natRule("DNAT", testAccPrefix+"dnat", mockVCDExternalIP, "10.10.102.5"),
+		natRule("DNAT", "", mockVCDExternalIP, "10.10.102.6"),
+		natRule("SNAT", testAccPrefix+"snat", "10.10.102.0/24", mockVCDExternalIP),
+		natRule("SNAT", "sweeper-keep", "10.10.102.0/24", mockVCDExternalIP),
+	}}
+	services.FirewallService = &types.FirewallService{IsEnabled: true, DefaultAction: "drop", FirewallRule: []*types.FirewallRule{
+		{Description: testAccPrefix + "rule"},
+		{Description: "sweeper-keep"},
+	}}
+	services.GatewayIpsecVpnService = &types.GatewayIpsecVpnService{IsEnabled: true, Tunnel: []*types.GatewayIpsecVpnTunnel{
+		{Name: testAccPrefix + "tunnel"},
+	}}
+	m.lock.Unlock()
+	defer func() {
+		m.lock.Lock()
+		*services = saved
+		m.lock.Unlock()
+	}()
+
+	for _, sweep := range []func(string) error{
+		testSweepVcdVApp,
+		testSweepVcdDNAT,
+		testSweepVcdSNAT,
+		testSweepVcdFirewallRules,
+		testSweepVcdEdgeGatewayVpn,
+		testSweepVcdNetwork,
+	} {
+		if err := sweep(mockVCDOrg); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	var names []string
+	for _, vApp := range m.vApps {
+		names = append(names, vApp.Name)
+	}
+	for _, network := range m.networks {
+		names = append(names, network.Name)
+	}
+	kept := 0
+	for _, name := range names {
+		if isTestAccName(name) {
+			t.Errorf("%s was not swept", name)
+		}
+		if name == "sweeper-keep" {
+			kept++
+		}
+	}
+	if kept != 2 {
+		t.Errorf("expected the other vApp and network to be kept, got %v", names)
+	}
+	var internalIPs []string
+	for _, rule := range services.NatService.NatRule {
+		internalIPs = append(internalIPs, rule.RuleType+" "+rule.GatewayNatRule.OriginalIP+" "+rule.GatewayNatRule.TranslatedIP)
+	}
+	if want := []string{"DNAT 203.0.113.10 10.10.102.6", "SNAT 10.10.102.0/24 203.0.113.10"}; !reflect.DeepEqual(internalIPs, want) {
+		t.Errorf("NAT rules swept to %v, want %v", internalIPs, want)
+	}
+	if rules := services.FirewallService.FirewallRule; len(rules) != 1 || rules[0].Description != "sweeper-keep" {
+		t.Errorf("firewall rules swept to %v", rules)
+	}
+	if vpn := services.GatewayIpsecVpnService; vpn.IsEnabled || len(vpn.Tunnel) != 0 {
+		t.Errorf("VPN service swept to %+v", vpn)
+	}
+}
@@ -0,0 +1,57 @@
+package vcd
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Session tokens are cached when cache_token is set, one file per URL,
+// user and org, readable only by the user running Terraform.
+const tokenCacheDir = ".terraform.d/vcd_token_cache"
+
+func tokenCachePath(href, user, org string) (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("could not find the home directory: %s", err)
+	}
+	key := sha256.Sum256([]byte(strings.Join([]string{href, user, org}, "\n")))
+	return filepath.Join(home, tokenCacheDir, hex.EncodeToString(key[:])), nil
+}
+
+// Returns the cached token, or an empty string if there is none.
+func readCachedToken(path string) string {
+	if path == "" {
+		return ""
+	}
+	token, err := ioutil.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(token))
+}
+
+func writeCachedToken(path, token string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	// Write to a temporary file first, so that concurrent runs never
+	// read a partial token
+	tmp, err := ioutil.TempFile(filepath.Dir(path), ".token")
+	if err != nil {
+		return err
+	}
+	_, err = tmp.WriteString(token)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,44 @@
+package vcd
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+)
+
+func TestTokenCache(t *testing.T) {
+	home, err := ioutil.TempDir("", "vcd-token-cache")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	defer os.RemoveAll(home)
+	defer os.Setenv("HOME", os.Getenv("HOME"))
+	os.Setenv("HOME", home)
+
+	path, err := tokenCachePath("https://vcd.example.com/api", "admin", "System")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	other, _ := tokenCachePath("https://vcd.example.com/api", "admin", "tenant")
+	if path == other {
+		t.Fatalf("orgs share the cache file %s", path)
+	}
+
+	if token := readCachedToken(path); token != "" {
+		t.Fatalf("expected no cached token, got %s", token)
+	}
+	if err := writeCachedToken(path, "abc123"); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if token := readCachedToken(path); token != "abc123" {
+		t.Fatalf("expected cached token abc123, got %s", token)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if mode := info.Mode().Perm(); mode != 0600 {
+		t.Fatalf("expected token cache mode 0600, got %o", mode)
+	}
+}